@@ -0,0 +1,47 @@
+package customtypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Upload is a custom GraphQL type that holds a file sent using the GraphQL multipart request spec.
+// It has to be added to a schema via "scalar Upload" since it is not a predeclared GraphQL type.
+type Upload struct {
+	// File gives streaming access to the contents of the file.
+	File        io.Reader
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
+// ImplementsGraphQLType maps this custom Go type
+// to the graphql scalar type in the schema.
+func (Upload) ImplementsGraphQLType(name string) bool {
+	return name == "Upload"
+}
+
+// UnmarshalGraphQL is a custom unmarshaler for Upload
+//
+// Uploads can only be passed in as variables, since
+// the http handler puts them into the variables map.
+func (u *Upload) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case *Upload:
+		*u = *input
+		return nil
+	case Upload:
+		*u = input
+		return nil
+	default:
+		return fmt.Errorf("wrong type")
+	}
+}
+
+// MarshalJSON is a custom marshaler for Upload
+//
+// The file contents are not sent back to clients, only the name of the file.
+func (u Upload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Filename)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"time"
 
 	"github.com/chirino/graphql/internal/exec"
 	"github.com/chirino/graphql/internal/introspection"
@@ -23,11 +24,25 @@ type Engine struct {
 	Logger         log.Logger
 	Resolver       resolvers.Resolver
 	Root           interface{}
+	// Middleware is called around the Resolver of every field, the first one is the outermost.  See
+	// resolvers.Hooks for a middleware that calls functions before and after each field.
+	Middleware []resolvers.Middleware
 	// Validate can be set to nil to disable validation.
 	Validate func(doc *schema.QueryDocument, maxDepth int) error
 	// OnRequest is called after the query is parsed but before the request is validated.
 	OnRequestHook func(request *Request, doc *schema.QueryDocument, op *schema.Operation) error
 	TryCast       func(value reflect.Value, toType string) (v reflect.Value, ok bool)
+	// PersistedQueries enables automatic persisted queries when set.
+	PersistedQueries PersistedQueryStore
+	// QueryCache enables caching of parsed and validated query documents when set.
+	QueryCache *QueryCache
+	// MaxComplexity rejects operations with a higher computed cost, 0 disables the cost analysis.
+	MaxComplexity int
+	// QueryTimeout and MutationTimeout limit how long an operation can execute, 0 disables the limit.
+	// The fields that are not resolved in time are null with a TIMEOUT error.  Fields can also be given
+	// their own timeout using the @timeout(ms:) directive in the schema.
+	QueryTimeout    time.Duration
+	MutationTimeout time.Duration
 }
 
 func CreateEngine(schema string) (*Engine, error) {
@@ -83,16 +98,36 @@ func (r responseStream) CloseWithErr(err error) responseStream {
 
 func (engine *Engine) ServeGraphQLStream(request *Request) ResponseStream {
 
-	doc := &schema.QueryDocument{}
-	err := doc.Parse(request.Query)
+	request, err := engine.resolvePersistedQuery(request)
 	if err != nil {
 		return NewErrStream(err)
 	}
 
+	var cacheKey queryCacheKey
+	var cached *queryCacheEntry
+	if engine.QueryCache != nil {
+		cacheKey = queryCacheKey{schema: engine.Schema, maxDepth: engine.MaxDepth, query: request.Query}
+		cached = engine.QueryCache.get(cacheKey)
+	}
+
+	var doc *schema.QueryDocument
+	if cached != nil {
+		doc = cached.doc
+	} else {
+		doc = &schema.QueryDocument{}
+		err = doc.Parse(request.Query)
+		if err != nil {
+			return NewErrStream(err)
+		}
+	}
+
 	op, err := doc.GetOperation(request.OperationName)
 	if err != nil {
 		return NewErrStream(err)
 	}
+	if request.ReadOnly && op.Type == schema.Mutation {
+		return NewErrStream(qerrors.New("mutations can't be executed by read only requests"))
+	}
 
 	if engine.OnRequestHook != nil {
 		err := engine.OnRequestHook(request, doc, op)
@@ -101,11 +136,19 @@ func (engine *Engine) ServeGraphQLStream(request *Request) ResponseStream {
 		}
 	}
 
-	if engine.Validate != nil {
-		err = engine.Validate(doc, engine.MaxDepth)
-		if err != nil {
-			return NewErrStream(err)
+	if cached != nil {
+		err = cached.validateErr
+	} else {
+		if engine.Validate != nil {
+			err = engine.Validate(doc, engine.MaxDepth)
 		}
+		if engine.QueryCache != nil {
+			cached = &queryCacheEntry{doc: doc, validateErr: err}
+			engine.QueryCache.add(cacheKey, cached)
+		}
+	}
+	if err != nil {
+		return NewErrStream(err)
 	}
 
 	varTypes := make(map[string]*introspection.Type)
@@ -125,6 +168,23 @@ func (engine *Engine) ServeGraphQLStream(request *Request) ResponseStream {
 		return NewErrStream(err)
 	}
 
+	var extensions interface{}
+	if engine.MaxComplexity > 0 {
+		complexity, err := validation.ValidateComplexity(engine.Schema, doc, op, variables, engine.MaxComplexity)
+		extensions = map[string]interface{}{
+			"complexity": complexity,
+		}
+		if err != nil {
+			rc := make(chan *Response, 1)
+			rc <- &Response{
+				Errors:     ErrorList{err},
+				Extensions: extensions,
+			}
+			close(rc)
+			return rc
+		}
+	}
+
 	responses := make(chan *Response, 1)
 	r := exec.Execution{
 		Context:        traceContext,
@@ -134,26 +194,53 @@ func (engine *Engine) ServeGraphQLStream(request *Request) ResponseStream {
 		Tracer:         engine.Tracer,
 		Logger:         engine.Logger,
 		Resolver:       engine.Resolver,
+		Middleware:     engine.Middleware,
 		Doc:            doc,
 		Operation:      op,
 		VarTypes:       varTypes,
 		MaxParallelism: engine.MaxParallelism,
 		Root:           engine.Root,
 		TryCast:        engine.TryCast,
+		Timeout:        engine.operationTimeout(op.Type),
 		FireSubscriptionEventFunc: func(d json.RawMessage, e qerrors.ErrorList) {
 			responses <- &Response{
-				Data:   d,
-				Errors: e,
+				Data:       d,
+				Errors:     e,
+				Extensions: extensions,
 			}
 			traceResponse(e)
 		},
 		FireSubscriptionCloseFunc: func() {
 			close(responses)
-			doc.Close()
+			// cached documents are shared with other requests.
+			if cached == nil {
+				doc.Close()
+			}
 			traceFinish()
 		},
 	}
 
+	if request.IncrementalDelivery {
+		r.FireIncrementalEventFunc = func(d json.RawMessage, e qerrors.ErrorList, path []interface{}, label string, hasNext bool) {
+			response := &Response{
+				Data:       d,
+				Errors:     e,
+				Label:      label,
+				HasNext:    &hasNext,
+				Extensions: extensions,
+			}
+			if path != nil {
+				response.Path = path
+			}
+			// subsequent payloads are sent async, don't block if the caller stopped reading them.
+			select {
+			case responses <- response:
+			case <-traceContext.Done():
+			}
+			traceResponse(e)
+		}
+	}
+
 	err = r.Execute()
 	if err != nil {
 		return NewErrStream(err)
@@ -161,6 +248,16 @@ func (engine *Engine) ServeGraphQLStream(request *Request) ResponseStream {
 	return responses
 }
 
+func (engine *Engine) operationTimeout(operationType schema.OperationType) time.Duration {
+	switch operationType {
+	case schema.Query:
+		return engine.QueryTimeout
+	case schema.Mutation:
+		return engine.MutationTimeout
+	}
+	return 0
+}
+
 func (engine *Engine) validate(doc *schema.QueryDocument, maxDepth int) error {
 	errs := validation.Validate(engine.Schema, doc, maxDepth)
 	if len(errs) != 0 {
@@ -2,15 +2,20 @@ package graphql_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/chirino/graphql"
 	"github.com/chirino/graphql/internal/gqltesting"
+	"github.com/chirino/graphql/qerrors"
 	"github.com/chirino/graphql/resolvers"
 	"github.com/chirino/graphql/schema"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -573,3 +578,668 @@ type MySubscription {
 
 	streamClose() // close out the subscription...
 }
+
+func TestQueryCache(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+	engine.QueryCache = graphql.NewQueryCache(10)
+
+	err := engine.Schema.Parse(schemaText)
+	require.NoError(t, err)
+
+	query := `{ person { name } ... on Query { person { age } } }`
+	for i := 0; i < 3; i++ {
+		gqltesting.AssertQuery(t, engine, query,
+			`{"data":{"person":{"name":"Hiram","age":35}}}`)
+	}
+	assert.Equal(t, uint64(1), engine.QueryCache.Misses())
+	assert.Equal(t, uint64(2), engine.QueryCache.Hits())
+
+	// validation failures are cached too.
+	for i := 0; i < 2; i++ {
+		gqltesting.AssertQuery(t, engine, `{ person { height } }`,
+			`{"errors":[{"message":"Cannot query field \"height\" on type \"Person\".","locations":[{"line":1,"column":12}]}]}`)
+	}
+	assert.Equal(t, uint64(2), engine.QueryCache.Misses())
+	assert.Equal(t, uint64(3), engine.QueryCache.Hits())
+	assert.Equal(t, 2, engine.QueryCache.Len())
+}
+
+func TestBatchResolver(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = map[string]interface{}{
+		"people": []map[string]interface{}{
+			{"name": "Hiram", "petId": 1},
+			{"name": "Ana", "petId": 2},
+			{"name": "Bob", "petId": 1},
+		},
+	}
+	err := engine.Schema.Parse(`
+schema {
+	query: Query
+}
+type Query {
+	people: [Person]
+}
+type Person {
+	name: String
+	pet: Dog
+}
+type Dog {
+	name: String
+}
+`)
+	require.NoError(t, err)
+
+	loads := [][]interface{}{}
+	engine.Resolver = resolvers.List(engine.Resolver, &resolvers.BatchResolver{
+		Key: func(request *resolvers.ResolveRequest) (interface{}, bool) {
+			if request.Field.Name != "pet" {
+				return nil, false
+			}
+			return request.Parent.Interface().(map[string]interface{})["petId"], true
+		},
+		Load: func(ctx context.Context, keys []interface{}) ([]interface{}, []error) {
+			loads = append(loads, keys)
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				values[i] = map[string]interface{}{"name": fmt.Sprintf("Dog %v", key)}
+			}
+			return values, nil
+		},
+	})
+
+	gqltesting.AssertQuery(t, engine, `{ people { name pet { name } } }`,
+		`{"data":{"people":[{"name":"Hiram","pet":{"name":"Dog 1"}},{"name":"Ana","pet":{"name":"Dog 2"}},{"name":"Bob","pet":{"name":"Dog 1"}}]}}`)
+	assert.Equal(t, [][]interface{}{{1, 2}}, loads)
+
+	// results are only cached for the duration of a request.
+	gqltesting.AssertQuery(t, engine, `{ people { pet { name } } }`,
+		`{"data":{"people":[{"pet":{"name":"Dog 1"}},{"pet":{"name":"Dog 2"}},{"pet":{"name":"Dog 1"}}]}}`)
+	assert.Equal(t, [][]interface{}{{1, 2}, {1, 2}}, loads)
+}
+
+// minimalExecutionContext only implements the methods of resolvers.ExecutionContext that the
+// BatchResolver uses.
+type minimalExecutionContext struct {
+	resolvers.ExecutionContext
+	limiter chan byte
+}
+
+func (c *minimalExecutionContext) GetLimiter() *chan byte {
+	return &c.limiter
+}
+
+func (c *minimalExecutionContext) GetContext() context.Context {
+	return context.Background()
+}
+
+func (c *minimalExecutionContext) HandlePanic(selectionPath []string) error {
+	return nil
+}
+
+func TestBatchResolverWithoutExecutionValues(t *testing.T) {
+	loads := [][]interface{}{}
+	batch := &resolvers.BatchResolver{
+		Key: func(request *resolvers.ResolveRequest) (interface{}, bool) {
+			return request.Args["id"], true
+		},
+		Load: func(ctx context.Context, keys []interface{}) ([]interface{}, []error) {
+			loads = append(loads, keys)
+			return keys, nil
+		},
+	}
+
+	// the keys of execution contexts that don't implement resolvers.ExecutionValues are loaded on their own.
+	ec := &minimalExecutionContext{limiter: make(chan byte, 1)}
+	for _, id := range []string{"1", "2"} {
+		value, err := batch.Resolve(&resolvers.ResolveRequest{
+			ExecutionContext: ec,
+			Args:             map[string]interface{}{"id": id},
+			SelectionPath:    func() []string { return nil },
+		}, nil)()
+		require.NoError(t, err)
+		assert.Equal(t, id, value.Interface())
+	}
+	assert.Equal(t, [][]interface{}{{"1"}, {"2"}}, loads)
+}
+
+func TestBatchResolverTimeout(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = map[string]interface{}{
+		"people": []map[string]interface{}{
+			{"name": "Hiram", "petId": 1},
+			{"name": "Ana", "petId": 2},
+		},
+	}
+	err := engine.Schema.Parse(`
+schema {
+	query: Query
+}
+type Query {
+	people: [Person]
+}
+type Person {
+	name: String
+	pet: Dog @timeout(ms: 20)
+}
+type Dog {
+	name: String
+}
+`)
+	require.NoError(t, err)
+
+	loadErrs := make(chan error, 1)
+	engine.Resolver = resolvers.List(engine.Resolver, &resolvers.BatchResolver{
+		Key: func(request *resolvers.ResolveRequest) (interface{}, bool) {
+			if request.Field.Name != "pet" {
+				return nil, false
+			}
+			return request.Parent.Interface().(map[string]interface{})["petId"], true
+		},
+		Load: func(ctx context.Context, keys []interface{}) ([]interface{}, []error) {
+			// a slow load that notices when its context is done, it still takes a moment to return so that
+			// the fields time out first.
+			select {
+			case <-ctx.Done():
+				loadErrs <- ctx.Err()
+			case <-time.After(time.Second):
+				loadErrs <- nil
+			}
+			time.Sleep(20 * time.Millisecond)
+			return make([]interface{}, len(keys)), nil
+		},
+	})
+
+	gqltesting.AssertQuery(t, engine, `{ people { name pet { name } } }`,
+		`{"data":{"people":[{"name":"Hiram","pet":null},{"name":"Ana","pet":null}]},"errors":[{"message":"field resolution timed out","path":["people","0","pet"],"extensions":{"code":"TIMEOUT"}},{"message":"field resolution timed out","path":["people","1","pet"],"extensions":{"code":"TIMEOUT"}}]}`)
+	assert.Equal(t, context.DeadlineExceeded, <-loadErrs)
+}
+
+func TestMaxComplexity(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+	engine.MaxComplexity = 3
+
+	err := engine.Schema.Parse(schemaText)
+	require.NoError(t, err)
+
+	gqltesting.AssertQuery(t, engine, `{ person { name age } }`,
+		`{"data":{"person":{"name":"Hiram","age":35}},"extensions":{"complexity":3}}`)
+	gqltesting.AssertQuery(t, engine, `{ person { name spouse { name } } }`,
+		`{"errors":[{"message":"Operation has complexity 4 that exceeds max complexity 3","locations":[{"line":1,"column":1}]}],"extensions":{"complexity":4}}`)
+}
+
+func TestDeferAndStream(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+
+	err := engine.Schema.Parse(schemaText)
+	require.NoError(t, err)
+
+	query := `{ person { name ... @defer(label: "spouse") { spouse { name } } pets @stream(initialCount: 1) { name } } }`
+
+	// the directives are ignored by callers that don't support incremental delivery.
+	gqltesting.AssertQuery(t, engine, query,
+		`{"data":{"person":{"name":"Hiram","spouse":{"name":"Ana"},"pets":[{"name":"Ginger"},{"name":"Cameron"}]}}}`)
+
+	stream := engine.ServeGraphQLStream(&graphql.Request{Query: query, IncrementalDelivery: true})
+	actual := []string{}
+	for response := range stream {
+		data, err := json.Marshal(response)
+		require.NoError(t, err)
+		actual = append(actual, string(data))
+	}
+	assert.Equal(t, []string{
+		`{"data":{"person":{"name":"Hiram","pets":[{"name":"Ginger"}]}},"hasNext":true}`,
+		`{"data":{"spouse":{"name":"Ana"}},"path":["person"],"label":"spouse","hasNext":true}`,
+		`{"data":{"name":"Cameron"},"path":["person","pets",1],"hasNext":false}`,
+	}, actual)
+}
+
+type deferQuery struct{}
+
+func (q *deferQuery) Parent() *deferParent {
+	return &deferParent{}
+}
+
+type deferParent struct{}
+
+func (p *deferParent) Name() string {
+	return "parent"
+}
+
+func (p *deferParent) Broken() (string, error) {
+	return "", errors.New("broken")
+}
+
+func TestDeferUnderNulledField(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = &deferQuery{}
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			parent: Parent
+		}
+		type Parent {
+			name: String
+			broken: String!
+		}
+	`)
+	require.NoError(t, err)
+
+	// the error nulls parent, so there is nothing left to apply the deferred part to.
+	query := `{ parent { broken ... @defer(label: "name") { name } } }`
+	stream := engine.ServeGraphQLStream(&graphql.Request{Query: query, IncrementalDelivery: true})
+	actual := []string{}
+	for response := range stream {
+		data, err := json.Marshal(response)
+		require.NoError(t, err)
+		actual = append(actual, string(data))
+	}
+	assert.Equal(t, []string{
+		`{"data":{"parent":null},"errors":[{"message":"broken","path":["parent","broken"]}]}`,
+	}, actual)
+}
+
+type slowQuery struct {
+	delay time.Duration
+}
+
+func (q *slowQuery) Fast() string {
+	return "fast"
+}
+
+func (q *slowQuery) Slow(ctx context.Context) string {
+	select {
+	case <-time.After(q.delay):
+	case <-ctx.Done():
+	}
+	return "slow"
+}
+
+func (q *slowQuery) Limited(ctx context.Context) string {
+	return q.Slow(ctx)
+}
+
+func TestTimeouts(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = &slowQuery{delay: time.Second}
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			fast: String
+			slow: String
+			limited: String @timeout(ms: 10)
+		}
+	`)
+	require.NoError(t, err)
+
+	gqltesting.AssertQuery(t, engine, `{ fast limited }`,
+		`{"data":{"fast":"fast","limited":null},"errors":[{"message":"field resolution timed out","path":["limited"],"extensions":{"code":"TIMEOUT"}}]}`)
+
+	engine.QueryTimeout = 10 * time.Millisecond
+	// the fields that are not resolved yet when the operation times out are null.
+	gqltesting.AssertQuery(t, engine, `{ fast slow alsoSlow: slow }`,
+		`{"data":{"fast":"fast","slow":null,"alsoSlow":null},"errors":[{"message":"field resolution timed out","path":["slow"],"extensions":{"code":"TIMEOUT"}},{"message":"field resolution timed out","path":["alsoSlow"],"extensions":{"code":"TIMEOUT"}}]}`)
+
+	engine.Root = &slowQuery{delay: time.Millisecond}
+	gqltesting.AssertQuery(t, engine, `{ slow fast limited }`,
+		`{"data":{"slow":"slow","fast":"fast","limited":"slow"}}`)
+}
+
+type nullQuery struct{}
+
+type nullItem struct {
+	name *string
+}
+
+func (i *nullItem) Name() *string {
+	return i.name
+}
+
+func (q *nullQuery) Item() *nullItem {
+	return &nullItem{}
+}
+
+func (q *nullQuery) RequiredItem() *nullItem {
+	return &nullItem{}
+}
+
+func (q *nullQuery) Items() []*nullItem {
+	name := "a"
+	return []*nullItem{{name: &name}, {}, nil}
+}
+
+func (q *nullQuery) RequiredItems() []*nullItem {
+	return q.Items()
+}
+
+func (q *nullQuery) Strings() []*string {
+	name := "a"
+	return []*string{&name, nil}
+}
+
+func (q *nullQuery) Outer() *nullQuery {
+	return q
+}
+
+func (q *nullQuery) RequiredOuter() *nullQuery {
+	return q
+}
+
+func (q *nullQuery) Hello() string {
+	return "hello"
+}
+
+func TestNullPropagation(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = &nullQuery{}
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			hello: String
+			item: Item
+			requiredItem: Item!
+			items: [Item]
+			requiredItems: [Item!]
+			strings: [String!]
+			outer: Query
+			requiredOuter: Query!
+		}
+		type Item {
+			name: String!
+		}
+	`)
+	require.NoError(t, err)
+
+	// the null of a non null field makes its parent object null.
+	gqltesting.AssertQuery(t, engine, `{ hello item { name } }`,
+		`{"data":{"hello":"hello","item":null},"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["item","name"]}]}`)
+
+	// null list elements are allowed by nullable element types.
+	gqltesting.AssertQuery(t, engine, `{ items { name } }`,
+		`{"data":{"items":[{"name":"a"},null,null]},"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["items","1","name"]}]}`)
+
+	// but they make the list null when the element type is non null.
+	gqltesting.AssertQuery(t, engine, `{ requiredItems { name } }`,
+		`{"data":{"requiredItems":null},"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["requiredItems","1","name"]}]}`)
+	gqltesting.AssertQuery(t, engine, `{ strings }`,
+		`{"data":{"strings":null},"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["strings","1"]}]}`)
+
+	// the null bubbles up through non null fields until it reaches a nullable one.
+	gqltesting.AssertQuery(t, engine, `{ hello outer { hello requiredOuter { requiredItem { name } } } }`,
+		`{"data":{"hello":"hello","outer":null},"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["outer","requiredOuter","requiredItem","name"]}]}`)
+
+	// and the whole data is null when it reaches the root.
+	gqltesting.AssertQuery(t, engine, `{ hello requiredItem { name } }`,
+		`{"data":null,"errors":[{"message":"ResolverFactory produced a nil value for a Non Null type","path":["requiredItem","name"]}]}`)
+}
+
+func TestSerialMutations(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+			mutation: Mutation
+		}
+		type Query {
+			value: Int
+		}
+		type Mutation {
+			add(value: Int!, delay: Int!): Int
+		}
+	`)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	total := 0
+	serial := []bool{}
+	engine.Resolver = resolvers.List(engine.Resolver, resolvers.Func(func(request *resolvers.ResolveRequest, next resolvers.Resolution) resolvers.Resolution {
+		if request.Field.Name != "add" {
+			return next
+		}
+		mu.Lock()
+		serial = append(serial, request.Serial)
+		mu.Unlock()
+		return request.RunAsync(func() (reflect.Value, error) {
+			time.Sleep(time.Duration(request.Args["delay"].(int32)) * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			total = total*10 + int(request.Args["value"].(int32))
+			return reflect.ValueOf(total), nil
+		})
+	}))
+
+	// the slow first field completes before the second one starts.
+	gqltesting.AssertQuery(t, engine, `mutation { a: add(value: 1, delay: 50) b: add(value: 2, delay: 0) c: add(value: 3, delay: 10) }`,
+		`{"data":{"a":1,"b":12,"c":123}}`)
+	assert.Equal(t, []bool{true, true, true}, serial)
+}
+
+func TestMiddleware(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+	err := engine.Schema.Parse(schemaText)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	resolved := []string{}
+	engine.Middleware = []resolvers.Middleware{
+		resolvers.Hooks{
+			Before: func(request *resolvers.ResolveRequest) error {
+				if request.Field.Name == "age" {
+					return errors.New("not authorized")
+				}
+				return nil
+			},
+			After: func(request *resolvers.ResolveRequest, value reflect.Value, err error, duration time.Duration) (reflect.Value, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				resolved = append(resolved, fmt.Sprintf("%s:%v", strings.Join(request.SelectionPath(), "."), err))
+				assert.True(t, duration >= 0)
+				return value, err
+			},
+		},
+		resolvers.MiddlewareFunc(func(request *resolvers.ResolveRequest, resolve func() resolvers.Resolution) resolvers.Resolution {
+			next := resolve()
+			if next == nil || request.Field.Name != "name" {
+				return next
+			}
+			return func() (reflect.Value, error) {
+				value, err := next()
+				if err != nil {
+					return value, err
+				}
+				return reflect.ValueOf(strings.ToUpper(value.Elem().String())), nil
+			}
+		}),
+	}
+
+	gqltesting.AssertQuery(t, engine, `{ person { name age } }`,
+		`{"data":{"person":{"name":"HIRAM","age":null}},"errors":[{"message":"not authorized","path":["person","age"]}]}`)
+	assert.Equal(t, []string{"person:<nil>", "person.name:<nil>", "person.age:not authorized"}, resolved)
+}
+
+func TestMiddlewareRunsBeforeAsyncResolvers(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = root()
+	err := engine.Schema.Parse(schemaText)
+	require.NoError(t, err)
+
+	var started int32
+	engine.Resolver = resolvers.List(resolvers.Func(func(request *resolvers.ResolveRequest, next resolvers.Resolution) resolvers.Resolution {
+		if request.Field.Name != "age" {
+			return next
+		}
+		return request.RunAsync(func() (reflect.Value, error) {
+			atomic.AddInt32(&started, 1)
+			return next()
+		})
+	}), engine.Resolver)
+
+	// the same error is returned for all the denied fields.
+	denied := qerrors.New("denied")
+	engine.Middleware = []resolvers.Middleware{
+		resolvers.Hooks{
+			Before: func(request *resolvers.ResolveRequest) error {
+				if request.Field.Name == "age" {
+					return denied
+				}
+				return nil
+			},
+		},
+	}
+
+	gqltesting.AssertQuery(t, engine, `{ person { age spouse { age } } }`,
+		`{"data":{"person":{"age":null,"spouse":{"age":null}}},"errors":[{"message":"denied","path":["person","age"]},{"message":"denied","path":["person","spouse","age"]}]}`)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&started))
+}
+
+func TestInterfacesImplementingInterfacesAndRepeatableDirectives(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = map[string]interface{}{
+		"image": map[string]interface{}{"__typename": "Image", "id": "1", "url": "http://example.com"},
+	}
+	err := engine.Schema.Parse(`
+		directive @tag(name: String) repeatable on FIELD
+		directive @once on FIELD
+		schema {
+			query: Query
+		}
+		type Query {
+			image: Resource
+		}
+		interface Node {
+			id: ID!
+		}
+		interface Resource implements Node {
+			id: ID!
+			url: String
+		}
+		type Image implements Resource & Node {
+			id: ID!
+			url: String
+		}
+	`)
+	require.NoError(t, err)
+
+	gqltesting.AssertQuery(t, engine, `{ 
+		resource: __type(name: "Resource") { interfaces { name } possibleTypes { name } } 
+		node: __type(name: "Node") { possibleTypes { name } } 
+	}`,
+		`{"data":{"resource":{"interfaces":[{"name":"Node"}],"possibleTypes":[{"name":"Image"}]},"node":{"possibleTypes":[{"name":"Image"}]}}}`)
+
+	gqltesting.AssertQuery(t, engine, `{ __schema { directives { name isRepeatable } } }`,
+		`{"data":{"__schema":{"directives":[{"name":"cost","isRepeatable":false},{"name":"defer","isRepeatable":false},{"name":"deprecated","isRepeatable":false},{"name":"include","isRepeatable":false},{"name":"once","isRepeatable":false},{"name":"oneOf","isRepeatable":false},{"name":"skip","isRepeatable":false},{"name":"specifiedBy","isRepeatable":false},{"name":"stream","isRepeatable":false},{"name":"tag","isRepeatable":true},{"name":"timeout","isRepeatable":false}]}}}`)
+
+	// fragments on the implemented interface can be spread within the implementing one.
+	gqltesting.AssertQuery(t, engine, `{ image @tag(name: "a") @tag(name: "b") { url ... on Node { id } } }`,
+		`{"data":{"image":{"url":"http://example.com","id":"1"}}}`)
+
+	gqltesting.AssertQuery(t, engine, `{ image @once @once { url } }`,
+		`{"errors":[{"message":"The directive \"once\" can only be used once at this location.","locations":[{"line":1,"column":9},{"line":1,"column":9}]}]}`)
+}
+
+type oneOfQuery struct{}
+
+type petBy struct {
+	ID       *string
+	Name     *string
+	Nickname *string
+}
+
+func (q *oneOfQuery) Pet(args struct {
+	By    petBy
+	Limit *int32
+}) string {
+	if args.By.ID != nil {
+		return "id:" + *args.By.ID
+	}
+	return "name:" + *args.By.Name
+}
+
+func TestSpecifiedByOneOfAndDeprecatedInputValues(t *testing.T) {
+	engine := graphql.New()
+	engine.Root = &oneOfQuery{}
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+		input PetBy @oneOf {
+			id: ID
+			name: String
+			nickname: String @deprecated(reason: "Use name")
+		}
+		type Query {
+			pet(by: PetBy!, limit: Int @deprecated): String
+		}
+	`)
+	require.NoError(t, err)
+
+	gqltesting.AssertQuery(t, engine, `{ 
+		uuid: __type(name: "UUID") { specifiedByURL isOneOf } 
+		petBy: __type(name: "PetBy") { isOneOf inputFields { name } all: inputFields(includeDeprecated: true) { name isDeprecated deprecationReason } } 
+	}`,
+		`{"data":{"uuid":{"specifiedByURL":"https://tools.ietf.org/html/rfc4122","isOneOf":null},"petBy":{"isOneOf":true,"inputFields":[{"name":"id"},{"name":"name"}],"all":[{"name":"id","isDeprecated":false,"deprecationReason":null},{"name":"name","isDeprecated":false,"deprecationReason":null},{"name":"nickname","isDeprecated":true,"deprecationReason":"Use name"}]}}}`)
+
+	gqltesting.AssertQuery(t, engine, `{ __type(name: "Query") { fields { args { name } all: args(includeDeprecated: true) { name deprecationReason } } } }`,
+		`{"data":{"__type":{"fields":[{"args":[{"name":"by"}],"all":[{"name":"by","deprecationReason":null},{"name":"limit","deprecationReason":"No longer supported"}]}]}}}`)
+
+	gqltesting.AssertQuery(t, engine, `{ pet(by: {name: "Rex"}) }`,
+		`{"data":{"pet":"name:Rex"}}`)
+	gqltesting.AssertQuery(t, engine, `{ pet(by: {id: "1", name: "Rex"}) }`,
+		`{"errors":[{"message":"Argument \"by\" has invalid value {id: \"1\", name: \"Rex\"}.\nOneOf Input Object \"PetBy\" must specify exactly one key.","locations":[{"line":1,"column":11}]}]}`)
+	gqltesting.AssertQuery(t, engine, `{ pet(by: {id: null}) }`,
+		`{"errors":[{"message":"Argument \"by\" has invalid value {id: null}.\nField \"PetBy.id\" must be non-null.","locations":[{"line":1,"column":11}]}]}`)
+	gqltesting.AssertQuery(t, engine, `query($id: ID) { pet(by: {id: $id}) }`,
+		`{"errors":[{"message":"Variable \"$id\" of type \"ID\" must be non-nullable to be used for OneOf Input Object \"PetBy\".","locations":[{"line":1,"column":7},{"line":1,"column":31}]}]}`)
+	// the variables used by a fragment are checked against the definitions of each operation using it.
+	gqltesting.AssertRequest(t, engine, graphql.Request{
+		Query:         `query A($id: ID!) { ...F } query B($id: ID) { ...F } fragment F on Query { pet(by: {id: $id}) }`,
+		OperationName: "A",
+		Variables:     map[string]interface{}{"id": "1"},
+	}, `{"errors":[{"message":"Variable \"$id\" of type \"ID\" must be non-nullable to be used for OneOf Input Object \"PetBy\".","locations":[{"line":1,"column":36},{"line":1,"column":89}]}]}`)
+
+	// values given by variables are checked when the field is executed.
+	gqltesting.AssertRequest(t, engine, graphql.Request{
+		Query:     `query($by: PetBy!) { pet(by: $by) }`,
+		Variables: map[string]interface{}{"by": map[string]interface{}{"id": "1"}},
+	}, `{"data":{"pet":"id:1"}}`)
+	gqltesting.AssertRequest(t, engine, graphql.Request{
+		Query:     `query($by: PetBy!) { pet(by: $by) }`,
+		Variables: map[string]interface{}{"by": map[string]interface{}{"id": "1", "name": "Rex"}},
+	}, `{"data":{"pet":null},"errors":[{"message":"OneOf Input Object \"PetBy\" must specify exactly one key.","path":["pet"]}]}`)
+
+	// the input values that are required can't be deprecated.
+	err = graphql.New().Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			pet(id: ID! @deprecated): String
+		}
+	`)
+	require.EqualError(t, err, `graphql: required input value "id" of "Query.pet" cannot be deprecated`)
+
+	err = graphql.New().Schema.Parse(`
+		schema {
+			query: Query
+		}
+		input PetBy @oneOf {
+			id: ID!
+		}
+		type Query {
+			pet(by: PetBy): String
+		}
+	`)
+	require.EqualError(t, err, `graphql: field "id" of oneOf input "PetBy" must be nullable`)
+}
@@ -17,17 +17,25 @@ func (f ServeGraphQLFunc) ServeGraphQL(request *Request) *Response {
 	return f(request)
 }
 
-func GetSchema(serveGraphQL ServeGraphQLFunc) (*schema.Schema, error) {
-	json, err := GetSchemaIntrospectionJSON(serveGraphQL)
+// IntrospectionOptions select the newer introspection fields that GetSchema queries, by default only
+// the fields that servers of all versions support are queried.
+type IntrospectionOptions = introspection.QueryOptions
+
+func GetSchema(serveGraphQL ServeGraphQLFunc, options ...IntrospectionOptions) (*schema.Schema, error) {
+	json, err := GetSchemaIntrospectionJSON(serveGraphQL, options...)
 	if err != nil {
 		return nil, err
 	}
 	return introspection.NewSchema(json)
 }
 
-func GetSchemaIntrospectionJSON(serveGraphQL ServeGraphQLFunc) ([]byte, error) {
+func GetSchemaIntrospectionJSON(serveGraphQL ServeGraphQLFunc, options ...IntrospectionOptions) ([]byte, error) {
+	query := introspection.Query
+	if len(options) > 0 {
+		query = introspection.NewQuery(options[0])
+	}
 	result := serveGraphQL(&Request{
-		Query: introspection.Query,
+		Query: query,
 	})
 	return result.Data, result.Error()
 }
@@ -0,0 +1,105 @@
+package httpgql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/customtypes"
+)
+
+type batchedRequest struct {
+	request  *graphql.Request
+	response chan *graphql.Response
+}
+
+// serveGraphQLBatched queues the request into the pending batch, the batch is sent once
+// the BatchInterval elapses.
+func (client *Client) serveGraphQLBatched(request *graphql.Request) *graphql.Response {
+	pending := &batchedRequest{request: request, response: make(chan *graphql.Response, 1)}
+
+	client.mu.Lock()
+	generation := client.batchGeneration
+	if len(client.batch) == 0 {
+		client.batchTimer = time.AfterFunc(client.BatchInterval, func() {
+			client.flushBatch(generation)
+		})
+	}
+	client.batch = append(client.batch, pending)
+	full := client.MaxBatchSize > 0 && len(client.batch) >= client.MaxBatchSize
+	client.mu.Unlock()
+	if full {
+		go client.flushBatch(generation)
+	}
+
+	ctx := request.GetContext()
+	select {
+	case response := <-pending.response:
+		return response
+	case <-ctx.Done():
+		return graphql.NewResponse().AddError(ctx.Err())
+	}
+}
+
+// flushBatch sends the pending batch and hands each request its response.  It does nothing if the batch of
+// that generation was already sent.
+func (client *Client) flushBatch(generation uint64) {
+	client.mu.Lock()
+	if generation != client.batchGeneration {
+		// a full batch was already sent before the interval elapsed.
+		client.mu.Unlock()
+		return
+	}
+	batch := client.batch
+	client.batch = nil
+	client.batchGeneration++
+	client.batchTimer.Stop()
+	client.mu.Unlock()
+
+	switch len(batch) {
+	case 1:
+		response := graphql.NewResponse()
+		body, err := json.Marshal(batch[0].request)
+		if err == nil {
+			err = client.post(batch[0].request.GetContext(), bytes.NewReader(body), "application/json", &response)
+		}
+		if err != nil {
+			response.AddError(err)
+		}
+		batch[0].response <- response
+		return
+	}
+
+	requests := make([]*graphql.Request, len(batch))
+	for i, pending := range batch {
+		requests[i] = pending.request
+	}
+	var responses []*graphql.Response
+	body, err := json.Marshal(requests)
+	if err == nil {
+		// the requests of the batch may have different contexts, so the batch is not tied to any of them.
+		err = client.post(context.Background(), bytes.NewReader(body), "application/json", &responses)
+	}
+	if err == nil && len(responses) != len(batch) {
+		err = fmt.Errorf("expected %d responses in the batch, but received %d", len(batch), len(responses))
+	}
+	for i, pending := range batch {
+		switch {
+		case err != nil:
+			pending.response <- graphql.NewResponse().AddError(err)
+		case responses[i] == nil:
+			pending.response <- graphql.NewResponse().AddError(fmt.Errorf("missing response %d in the batch", i))
+		default:
+			pending.response <- responses[i]
+		}
+	}
+}
+
+func hasUploads(request *graphql.Request) bool {
+	uploads := map[string]*customtypes.Upload{}
+	extractUploads(request.Variables, "variables", uploads)
+	return len(uploads) > 0
+}
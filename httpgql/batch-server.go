@@ -0,0 +1,90 @@
+package httpgql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/chirino/graphql"
+)
+
+// DefaultBatchConcurrency is how many operations of a batch are executed concurrently
+// when Handler.BatchConcurrency is not set.
+const DefaultBatchConcurrency = 10
+
+// decodeOperations decodes either a single request object or a batch of requests sent as a json array.
+func decodeOperations(data []byte, request *graphql.Request) (batch []graphql.Request, err error) {
+	if !isBatch(data) {
+		return nil, json.Unmarshal(data, request)
+	}
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, err
+	}
+	if len(batch) == 0 {
+		return nil, fmt.Errorf("the request batch is empty")
+	}
+	return batch, nil
+}
+
+func isBatch(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '['
+}
+
+// serveBatch executes the operations of a batch concurrently and responds with a json
+// array holding their responses in the same order.
+func (h *Handler) serveBatch(w http.ResponseWriter, ctx context.Context, handlerFunc graphql.ServeGraphQLFunc, batch []graphql.Request, mediaType string) {
+	if h.MaxBatchSize > 0 && len(batch) > h.MaxBatchSize {
+		http.Error(w, fmt.Sprintf("the request batch exceeds the max batch size of %d", h.MaxBatchSize), http.StatusBadRequest)
+		return
+	}
+	concurrency := h.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	responses := make([]*graphql.Response, len(batch))
+	limiter := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := range batch {
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			request := &batch[i]
+			request.Context = ctx
+			responses[i] = handlerFunc(request)
+		}(i)
+	}
+	wg.Wait()
+
+	writeResponseHeader(ctx, w)
+	w.Header().Set("Content-Type", mediaType)
+	if h.Indent != "" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", h.Indent)
+		err := encoder.Encode(responses)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	io.WriteString(w, "[")
+	for i, response := range responses {
+		if i > 0 {
+			io.WriteString(w, ",")
+		}
+		if err := h.writeResponse(w, response); err != nil {
+			return
+		}
+	}
+	io.WriteString(w, "]\n")
+}
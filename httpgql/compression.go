@@ -0,0 +1,122 @@
+package httpgql
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Encoder creates a writer that compresses the data written to w using a content encoding.
+type Encoder func(w io.Writer) io.WriteCloser
+
+// DefaultEncoders are the content encodings used when Handler.Encoders is not set.  Brotli
+// needs a third party library, add a "br" Encoder to Handler.Encoders to support it.
+var DefaultEncoders = map[string]Encoder{
+	"gzip": func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	},
+	// the deflate content encoding is actually the zlib format.
+	"deflate": func(w io.Writer) io.WriteCloser {
+		return zlib.NewWriter(w)
+	},
+}
+
+// encodingPreference breaks ties between content encodings that the client accepts equally.
+var encodingPreference = []string{"br", "gzip", "deflate"}
+
+type compressedResponseWriter struct {
+	http.ResponseWriter
+	encoder io.WriteCloser
+}
+
+func (w *compressedResponseWriter) Write(data []byte) (int, error) {
+	return w.encoder.Write(data)
+}
+
+// Flush sends the data buffered by the encoder so that streamed responses are delivered as they are produced.
+func (w *compressedResponseWriter) Flush() {
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// compress wraps the response writer with the content encoding accepted by the client.  The
+// returned close function must be called once the response has been written.
+func (h *Handler) compress(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	if !h.Compression {
+		return w, func() {}
+	}
+	encoders := h.Encoders
+	if encoders == nil {
+		encoders = DefaultEncoders
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	encoding := negotiateEncoding(r, encoders)
+	if encoding == "" {
+		return w, func() {}
+	}
+	w.Header().Set("Content-Encoding", encoding)
+	cw := &compressedResponseWriter{ResponseWriter: w, encoder: encoders[encoding](w)}
+	return cw, func() {
+		cw.encoder.Close()
+	}
+}
+
+// negotiateEncoding picks the content encoding that the client accepts with the highest quality value.
+func negotiateEncoding(r *http.Request, encoders map[string]Encoder) string {
+	accepted := map[string]float64{}
+	for _, header := range r.Header["Accept-Encoding"] {
+		for _, value := range strings.Split(header, ",") {
+			// parse it like a media type to get at the q parameter.
+			name, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+			if err != nil {
+				continue
+			}
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				quality, err = strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+			}
+			accepted[name] = quality
+		}
+	}
+
+	candidates := []string{}
+	for _, name := range encodingPreference {
+		if encoders[name] != nil {
+			candidates = append(candidates, name)
+		}
+	}
+	others := []string{}
+	for name := range encoders {
+		if !contains(encodingPreference, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	candidates = append(candidates, others...)
+
+	result := ""
+	resultQuality := 0.0
+	for _, name := range candidates {
+		quality, ok := accepted[name]
+		if !ok {
+			quality = accepted["*"]
+		}
+		if quality > resultQuality {
+			result = name
+			resultQuality = quality
+		}
+	}
+	return result
+}
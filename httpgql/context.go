@@ -0,0 +1,107 @@
+package httpgql
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+type contextKey int
+
+const (
+	requestKey contextKey = iota
+	responseWriterKey
+	responseHeaderKey
+)
+
+// responseHeader collects the headers set by resolvers, so that concurrent resolvers do not
+// race with each other or with the handler writing the response.
+type responseHeader struct {
+	mu      sync.Mutex
+	header  http.Header
+	written bool
+}
+
+// withHTTP attaches the response and request to the context, in case a resolver wants to
+// work at the the http level.
+func withHTTP(ctx context.Context, w http.ResponseWriter, r *http.Request, headerWritten bool) context.Context {
+	ctx = context.WithValue(ctx, requestKey, r)
+	ctx = context.WithValue(ctx, responseWriterKey, w)
+	// Deprecated: the string keys used before RequestFromContext and ResponseWriterFromContext were added,
+	// they will be removed in the next release.
+	ctx = context.WithValue(ctx, "net/http.ResponseWriter", w)
+	ctx = context.WithValue(ctx, "*net/http.Request", r)
+	return context.WithValue(ctx, responseHeaderKey, &responseHeader{header: http.Header{}, written: headerWritten})
+}
+
+// RequestFromContext returns the http request that is being served, or nil when the
+// context does not come from a Handler.
+func RequestFromContext(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(requestKey).(*http.Request)
+	return r
+}
+
+// ResponseWriterFromContext returns the writer of the http response, or nil when the context
+// does not come from a Handler.  Resolvers should use SetResponseHeader or SetCookie to change
+// the response headers since the handler writes the response once the request has been executed.
+func ResponseWriterFromContext(ctx context.Context) http.ResponseWriter {
+	w, _ := ctx.Value(responseWriterKey).(http.ResponseWriter)
+	return w
+}
+
+// SetResponseHeader sets a header of the http response.  It returns false if the header could not be set
+// because the response has already started, which is the case for streamed responses once the stream
+// has started.  Operations received over a websocket, and the OnConnectionInit hook, can never set headers
+// since the upgrade response is sent before the first websocket message is read.
+func SetResponseHeader(ctx context.Context, key, value string) bool {
+	return updateResponseHeader(ctx, func(header http.Header) {
+		header.Set(key, value)
+	})
+}
+
+// AddResponseHeader adds a header value to the http response, see SetResponseHeader.
+func AddResponseHeader(ctx context.Context, key, value string) bool {
+	return updateResponseHeader(ctx, func(header http.Header) {
+		header.Add(key, value)
+	})
+}
+
+// SetCookie adds a Set-Cookie header to the http response, see SetResponseHeader.
+func SetCookie(ctx context.Context, cookie *http.Cookie) bool {
+	value := cookie.String()
+	if value == "" {
+		return false
+	}
+	return AddResponseHeader(ctx, "Set-Cookie", value)
+}
+
+func updateResponseHeader(ctx context.Context, update func(header http.Header)) bool {
+	h, _ := ctx.Value(responseHeaderKey).(*responseHeader)
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.written {
+		return false
+	}
+	update(h.header)
+	return true
+}
+
+// writeResponseHeader copies the headers set by the resolvers to the response.  Headers set
+// after that point are rejected.
+func writeResponseHeader(ctx context.Context, w http.ResponseWriter) {
+	h, _ := ctx.Value(responseHeaderKey).(*responseHeader)
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for key, values := range h.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	h.written = true
+}
@@ -0,0 +1,89 @@
+package httpgql_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/httpgql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextResolver struct{}
+
+func (contextResolver) Login(ctx context.Context) bool {
+	r := httpgql.RequestFromContext(ctx)
+	if r == nil || r.Header.Get("X-User") == "" || httpgql.ResponseWriterFromContext(ctx) == nil {
+		return false
+	}
+	httpgql.SetResponseHeader(ctx, "X-Logged-In", r.Header.Get("X-User"))
+	return httpgql.SetCookie(ctx, &http.Cookie{Name: "session", Value: "1234"})
+}
+
+// Legacy reads the deprecated context keys.
+func (contextResolver) Legacy(ctx context.Context) bool {
+	_, ok := ctx.Value("*net/http.Request").(*http.Request)
+	if !ok {
+		return false
+	}
+	_, ok = ctx.Value("net/http.ResponseWriter").(http.ResponseWriter)
+	return ok
+}
+
+func newContextEngine(t *testing.T) *graphql.Engine {
+	engine := graphql.New()
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			login: Boolean
+			legacy: Boolean
+		}
+	`)
+	require.NoError(t, err)
+	engine.Root = contextResolver{}
+	return engine
+}
+
+func TestContextAccessors(t *testing.T) {
+	engine := newContextEngine(t)
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ login }"}`))
+	r.Header.Set("X-User", "hiram")
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":{"login":true}}`+"\n", w.Body.String())
+	assert.Equal(t, "hiram", w.Header().Get("X-Logged-In"))
+	assert.Equal(t, "session=1234", w.Header().Get("Set-Cookie"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ legacy }"}`)))
+	assert.Equal(t, `{"data":{"legacy":true}}`+"\n", w.Body.String())
+
+	assert.Nil(t, httpgql.RequestFromContext(context.Background()))
+	assert.Nil(t, httpgql.ResponseWriterFromContext(context.Background()))
+	assert.False(t, httpgql.SetResponseHeader(context.Background(), "X-Test", "test"))
+}
+
+func TestContextAccessorsOverWebsocket(t *testing.T) {
+	engine := newContextEngine(t)
+	s := httptest.NewServer(&httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream})
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.RequestHeader.Set("X-User", "hiram")
+	stream := client.ServeGraphQLStream(&graphql.Request{Query: "{ login }"})
+	response := <-stream
+	require.NotNil(t, response)
+	require.NoError(t, response.Error())
+	// the upgrade response has been sent, so the headers can't be set anymore.
+	assert.Equal(t, `{"login":false}`, string(response.Data))
+}
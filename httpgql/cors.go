@@ -0,0 +1,147 @@
+package httpgql
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCORSAllowedHeaders are the request headers allowed when CORSPolicy.AllowedHeaders is not set.
+var DefaultCORSAllowedHeaders = []string{"Content-Type", "Authorization", "GraphQL-Require-Preflight"}
+
+// DefaultCSRFPreventionHeaders are the headers that mark a request as safe when
+// Handler.CSRFPreventionHeaders is not set.
+var DefaultCSRFPreventionHeaders = []string{"GraphQL-Require-Preflight", "Apollo-Require-Preflight", "X-Requested-With"}
+
+// CORSPolicy configures how the Handler answers cross-origin requests.
+type CORSPolicy struct {
+	// AllowedOrigins lists the origins allowed to call the handler, "*" allows all origins.
+	AllowedOrigins []string
+	// AllowOriginFunc is used instead of AllowedOrigins when set.
+	AllowOriginFunc func(origin string) bool
+	// AllowedHeaders lists the request headers clients can use, "*" allows all headers.  Defaults
+	// to DefaultCORSAllowedHeaders.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers that clients can read.
+	ExposedHeaders []string
+	// AllowCredentials allows clients to send cookies and http authentication.
+	AllowCredentials bool
+	// MaxAge is how long clients can cache the result of a preflight request.
+	MaxAge time.Duration
+}
+
+func (p *CORSPolicy) allowsOrigin(origin string) bool {
+	if p.AllowOriginFunc != nil {
+		return p.AllowOriginFunc(origin)
+	}
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// handle adds the CORS response headers.  It returns true if the request was a
+// preflight request that has been answered.
+func (p *CORSPolicy) handle(w http.ResponseWriter, r *http.Request) bool {
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	if origin == "" || !p.allowsOrigin(origin) {
+		if preflight {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		return preflight
+	}
+
+	if p.AllowOriginFunc == nil && !p.AllowCredentials && contains(p.AllowedOrigins, "*") {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if !preflight {
+		if len(p.ExposedHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(p.ExposedHeaders, ", "))
+		}
+		return false
+	}
+
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+	header.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	allowedHeaders := p.AllowedHeaders
+	if allowedHeaders == nil {
+		allowedHeaders = DefaultCORSAllowedHeaders
+	}
+	if contains(allowedHeaders, "*") {
+		if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+			header.Set("Access-Control-Allow-Headers", requested)
+		}
+	} else if len(allowedHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+	}
+	if p.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(p.MaxAge/time.Second)))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// checkOrigin is used to accept websocket upgrades from the same host or from an allowed origin.
+func (p *CORSPolicy) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return p.allowsOrigin(origin)
+}
+
+// preflighted returns true if a browser would have sent a CORS preflight request before sending
+// the request, which is the case when it has a non-simple content type or a custom header.
+func (h *Handler) preflighted(r *http.Request) bool {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return true
+		}
+		switch mediaType {
+		case "application/x-www-form-urlencoded", "multipart/form-data", "text/plain":
+		default:
+			return true
+		}
+	}
+	for _, name := range h.csrfPreventionHeaders() {
+		if r.Header.Get(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) csrfPreventionHeaders() []string {
+	if h.CSRFPreventionHeaders == nil {
+		return DefaultCSRFPreventionHeaders
+	}
+	return h.CSRFPreventionHeaders
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
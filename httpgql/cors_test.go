@@ -0,0 +1,104 @@
+package httpgql_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/httpgql"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func helloWorld(request *graphql.Request) *graphql.Response {
+	return &graphql.Response{Data: []byte(`{"hello":"world"}`)}
+}
+
+func TestCORS(t *testing.T) {
+	h := httpgql.Handler{
+		ServeGraphQL: helloWorld,
+		CORS: &httpgql.CORSPolicy{
+			AllowedOrigins:   []string{"https://app.example.com"},
+			AllowCredentials: true,
+			ExposedHeaders:   []string{"X-Trace-Id"},
+			MaxAge:           time.Hour,
+		},
+	}
+
+	r := httptest.NewRequest("OPTIONS", "/", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 204, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization, GraphQL-Require-Preflight", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3600", w.Header().Get("Access-Control-Max-Age"))
+
+	r = httptest.NewRequest("OPTIONS", "/", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 403, w.Code)
+	assert.Equal(t, "", w.Header().Get("Access-Control-Allow-Origin"))
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{hello}"}`))
+	r.Header.Set("Origin", "https://app.example.com")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Trace-Id", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, `{"data":{"hello":"world"}}`+"\n", w.Body.String())
+}
+
+func TestCSRFPrevention(t *testing.T) {
+	h := httpgql.Handler{ServeGraphQL: helloWorld, CSRFPrevention: true}
+	serve := func(method string, contentType string, header string) int {
+		r := httptest.NewRequest(method, "/?query={hello}", strings.NewReader(`{"query":"{hello}"}`))
+		if contentType != "" {
+			r.Header.Set("Content-Type", contentType)
+		}
+		if header != "" {
+			r.Header.Set(header, "true")
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, 400, serve("GET", "", ""))
+	assert.Equal(t, 400, serve("POST", "text/plain", ""))
+	assert.Equal(t, 200, serve("GET", "", "GraphQL-Require-Preflight"))
+	assert.Equal(t, 200, serve("POST", "application/json", ""))
+	assert.Equal(t, 200, serve("POST", "", "X-Requested-With"))
+}
+
+func TestWebsocketCheckOrigin(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: helloWorldStream,
+		CORS:               &httpgql.CORSPolicy{AllowedOrigins: []string{"https://app.example.com"}},
+	})
+	defer s.Close()
+	wsUrl, err := httpgql.ToWsURL(s.URL)
+	require.NoError(t, err)
+
+	dial := func(origin string) error {
+		dialer := websocket.Dialer{Subprotocols: []string{httpgql.GraphQLTransportWS}}
+		conn, _, err := dialer.Dial(wsUrl, http.Header{"Origin": []string{origin}})
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+	assert.NoError(t, dial("https://app.example.com"))
+	assert.NoError(t, dial(s.URL))
+	assert.Error(t, dial("https://evil.example.com"))
+}
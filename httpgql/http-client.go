@@ -1,7 +1,7 @@
 package httpgql
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/chirino/graphql"
 	"github.com/chirino/graphql/qerrors"
@@ -19,7 +20,28 @@ type Client struct {
 	HTTPClient    *http.Client
 	connections   map[string]*wsConnection
 	RequestHeader http.Header
-	mu            sync.Mutex
+	// UsePersistedQueries sends the query hash first and only sends the full
+	// query text when the server does not know the hash yet.
+	UsePersistedQueries         bool
+	persistedQueriesUnsupported bool
+	// UseServerSentEvents makes ServeGraphQLStream receive the responses as server-sent
+	// events instead of using a websocket.
+	UseServerSentEvents bool
+	// ConnectionInitPayload is sent as the payload of the websocket connection_init message.
+	ConnectionInitPayload json.RawMessage
+	// Reconnect enables reconnecting failed websocket connections and restarting their streams.
+	Reconnect *ReconnectPolicy
+	// BatchInterval enables coalescing the requests issued by ServeGraphQL within that interval
+	// into a single batch request.
+	BatchInterval time.Duration
+	// MaxBatchSize sends the pending batch as soon as it holds that many requests.
+	MaxBatchSize int
+	batch        []*batchedRequest
+	// batchTimer flushes the pending batch once the BatchInterval elapses, batchGeneration is incremented
+	// each time a batch is sent so that a timer that already fired can't send the next batch.
+	batchTimer      *time.Timer
+	batchGeneration uint64
+	mu              sync.Mutex
 }
 
 func NewClient(url string) *Client {
@@ -31,23 +53,71 @@ func NewClient(url string) *Client {
 }
 
 func (client *Client) ServeGraphQL(request *graphql.Request) *graphql.Response {
-	c := client.HTTPClient
-	if c == nil {
-		c = &http.Client{}
+	client.mu.Lock()
+	usePersistedQueries := client.UsePersistedQueries && !client.persistedQueriesUnsupported
+	client.mu.Unlock()
+
+	if usePersistedQueries && request.Query != "" {
+		requestCp := *request
+		requestCp.Extensions = map[string]interface{}{}
+		for k, v := range request.Extensions {
+			requestCp.Extensions[k] = v
+		}
+		requestCp.SetPersistedQuery(graphql.PersistedQueryHash(request.Query))
+
+		// try without the query text first...
+		hashOnly := requestCp
+		hashOnly.Query = ""
+		response := client.serveGraphQL(&hashOnly)
+		switch {
+		case graphql.HasErrorMessage(response.Errors, graphql.PersistedQueryNotSupported):
+			// don't bother sending hashes to this server anymore.
+			client.mu.Lock()
+			client.persistedQueriesUnsupported = true
+			client.mu.Unlock()
+			return client.serveGraphQL(request)
+		case graphql.HasErrorMessage(response.Errors, graphql.PersistedQueryNotFound):
+			return client.serveGraphQL(&requestCp)
+		}
+		return response
+	}
+	return client.serveGraphQL(request)
+}
+
+func (client *Client) serveGraphQL(request *graphql.Request) *graphql.Response {
+	if client.BatchInterval > 0 && !hasUploads(request) {
+		return client.serveGraphQLBatched(request)
 	}
 
 	response := graphql.NewResponse()
-	body, err := json.Marshal(request)
+	body, contentType, err := encodeRequest(request)
 	if err != nil {
 		return response.AddError(err)
 	}
-
-	req, err := http.NewRequestWithContext(request.GetContext(), http.MethodPost, client.URL, bytes.NewReader(body))
+	err = client.post(request.GetContext(), body, contentType, &response)
 	if err != nil {
 		return response.AddError(err)
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
+	return response
+}
+
+// post sends the body to the server and decodes the json response into result.
+func (client *Client) post(ctx context.Context, body io.Reader, contentType string, result interface{}) error {
+	c := client.HTTPClient
+	if c == nil {
+		c = &http.Client{}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.URL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", graphQLResponseMediaType+", application/json")
+	req.Header.Set("Content-Type", contentType)
+	if strings.HasPrefix(contentType, "multipart/") {
+		// multipart is a simple content type, so servers guarding against CSRF need another hint.
+		req.Header.Set("GraphQL-Require-Preflight", "true")
+	}
 
 	for k, h := range client.RequestHeader {
 		req.Header[k] = h
@@ -55,19 +125,15 @@ func (client *Client) ServeGraphQL(request *graphql.Request) *graphql.Response {
 
 	resp, err := c.Do(req)
 	if err != nil {
-		return response.AddError(err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	contentType := resp.Header.Get("Content-Type")
-	if strings.HasPrefix(contentType, "application/json") {
-		err = json.NewDecoder(resp.Body).Decode(&response)
-		if err != nil {
-			return response.AddError(err)
-		}
-		return response
+	contentType = resp.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, graphQLResponseMediaType) {
+		return json.NewDecoder(resp.Body).Decode(result)
 	}
 
 	preview, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
-	return response.AddError(qerrors.Errorf("invalid content type: %s", contentType).WithCause(errors.New(string(preview))))
+	return qerrors.Errorf("invalid content type: %s", contentType).WithCause(errors.New(string(preview)))
 }
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/schema"
 )
 
 type Handler struct {
@@ -15,6 +20,46 @@ type Handler struct {
 	ServeGraphQLStream  graphql.ServeGraphQLStreamFunc
 	MaxRequestSizeBytes int64
 	Indent              string
+	// MaxUploadSizeBytes limits the total size of multipart file upload requests, MaxRequestSizeBytes is
+	// used when it's not set.
+	MaxUploadSizeBytes int64
+	// MaxFileSizeBytes limits the size of each file of a multipart file upload request, it's checked while
+	// the file is received.
+	MaxFileSizeBytes int64
+	// BatchConcurrency limits how many operations of a batch request are executed concurrently.
+	// Defaults to DefaultBatchConcurrency.
+	BatchConcurrency int
+	// MaxBatchSize limits how many operations a batch request can hold.
+	MaxBatchSize int
+	// CORS enables answering cross-origin requests, including the preflight OPTIONS requests.  It
+	// also controls which origins can open websocket connections.
+	CORS *CORSPolicy
+	// CSRFPrevention rejects the GET and POST requests that a browser could send without a CORS
+	// preflight request: the ones without a non-simple content type or one of the CSRFPreventionHeaders.
+	CSRFPrevention bool
+	// CSRFPreventionHeaders defaults to DefaultCSRFPreventionHeaders.
+	CSRFPreventionHeaders []string
+	// Compression enables compressing responses using a content encoding accepted by the client.
+	Compression bool
+	// Encoders are the content encodings used by Compression.  Defaults to DefaultEncoders.
+	Encoders map[string]Encoder
+	// StreamResponseData writes the already serialized Response.Data as is instead of re-encoding it,
+	// which avoids holding a second copy of large responses.  It has no effect when Indent is set.
+	StreamResponseData bool
+	// ConnectionInitWaitTimeout is how long graphql-transport-ws clients have to send the
+	// connection_init message.  Defaults to DefaultConnectionInitWaitTimeout.
+	ConnectionInitWaitTimeout time.Duration
+	// OnConnectionInit is called with the payload of the websocket connection_init message.  Return an
+	// error to reject the connection.  The returned context is used by all the requests of the connection.
+	// The upgrade response has already been sent by then, so it can't set response headers or cookies.
+	OnConnectionInit func(ctx context.Context, payload json.RawMessage) (context.Context, error)
+	// KeepAliveInterval enables sending websocket pings and keep-alive messages (ka for the legacy
+	// protocol and ping for graphql-transport-ws) at that interval.
+	KeepAliveInterval time.Duration
+	// ReadTimeout closes websocket connections that did not send a message or a pong for that long.
+	ReadTimeout time.Duration
+	// MaxIdleTime closes websocket connections that have had no active streams for that long.
+	MaxIdleTime time.Duration
 }
 
 type OperationMessage struct {
@@ -36,22 +81,48 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		panic("either HandlerFunc or StreamingHandlerFunc must be configured")
 	}
 
+	if h.CORS != nil && h.CORS.handle(w, r) {
+		return
+	}
+
 	if streamingHandlerFunc != nil {
 		u := strings.ToLower(r.Header.Get("Upgrade"))
 		if u == "websocket" {
-			Upgrade(w, r, streamingHandlerFunc)
+			h.upgrade(w, r, streamingHandlerFunc)
 			return
 		}
 	}
 
+	if h.CSRFPrevention && !h.preflighted(r) {
+		http.Error(w, "this request has been blocked as a potential Cross-Site Request Forgery, send it "+
+			"with a non-simple Content-Type or one of these headers: "+strings.Join(h.csrfPreventionHeaders(), ", "), http.StatusBadRequest)
+		return
+	}
+
+	w, closeEncoder := h.compress(w, r)
+	defer closeEncoder()
+
 	defer r.Body.Close()
 	var request graphql.Request
+	var batch []graphql.Request
 
 	switch r.Method {
 	case http.MethodGet:
 		request.Query = r.URL.Query().Get("query")
 		request.Variables = json.RawMessage(r.URL.Query().Get("variables"))
 		request.OperationName = r.URL.Query().Get("operationName")
+		if err := decodeExtensions(r, &request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// GET requests should not have side effects.  Persisted queries sent by hash are only known by the
+		// engine, so it's also asked to reject mutations.
+		request.ReadOnly = true
+		if isMutation(&request) {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "mutations can only be sent using a POST request", http.StatusMethodNotAllowed)
+			return
+		}
 	case http.MethodPost:
 
 		reader := r.Body.(io.Reader)
@@ -59,10 +130,46 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			reader = io.LimitReader(reader, h.MaxRequestSizeBytes)
 		}
 
-		if err := json.NewDecoder(reader).Decode(&request); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch {
+		case contentType == "multipart/form-data":
+			operations, closeFiles, status, err := h.decodeMultipart(r, &request)
+			defer closeFiles()
+			if err != nil {
+				http.Error(w, err.Error(), status)
+				return
+			}
+			batch = operations
+
+		case contentType == "application/graphql":
+			// the body holds the query document, the other fields can be sent as query parameters.
+			query, err := ioutil.ReadAll(reader)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			request.Query = string(query)
+
+		case contentType == "", contentType == "application/json", strings.HasSuffix(contentType, "+json"):
+			var body json.RawMessage
+			if err := json.NewDecoder(reader).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			operations, err := decodeOperations(body, &request)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			batch = operations
+
+		default:
+			http.Error(w, "unsupported content type: "+contentType, http.StatusUnsupportedMediaType)
 			return
 		}
+		if batch != nil {
+			break
+		}
 
 		// Fallback to using query parameters
 		if request.Query == "" {
@@ -74,27 +181,204 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if request.OperationName == "" {
 			request.OperationName = r.URL.Query().Get("operationName")
 		}
+		if request.Extensions == nil {
+			if err := decodeExtensions(r, &request); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
 
 	default:
-		w.WriteHeader(http.StatusBadRequest)
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "unsupported method: "+r.Method, http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Attach the response and request to the context, in case a resolver wants to
-	// work at the the http level.
-	ctx := r.Context()
-	ctx = context.WithValue(ctx, "net/http.ResponseWriter", w)
-	ctx = context.WithValue(ctx, "*net/http.Request", r)
+	ctx := withHTTP(r.Context(), w, r, false)
 
 	request.Context = ctx
+	if batch == nil && streamingHandlerFunc != nil && accepts(r, "text/event-stream") {
+		request.IncrementalDelivery = true
+		var cancel context.CancelFunc
+		request.Context, cancel = context.WithCancel(ctx)
+		defer cancel()
+		stream := streamingHandlerFunc(&request)
+		writeResponseHeader(ctx, w)
+		h.serveEventStream(w, stream, cancel)
+		return
+	}
+	if batch == nil && streamingHandlerFunc != nil && accepts(r, "multipart/mixed") {
+		request.IncrementalDelivery = true
+		var cancel context.CancelFunc
+		request.Context, cancel = context.WithCancel(ctx)
+		defer cancel()
+		stream := streamingHandlerFunc(&request)
+		writeResponseHeader(ctx, w)
+		h.serveMultipart(w, stream, cancel)
+		return
+	}
+
+	mediaType, ok := negotiateMediaType(r)
+	if !ok {
+		http.Error(w, "the response can only be sent as "+graphQLResponseMediaType+" or application/json", http.StatusNotAcceptable)
+		return
+	}
+	if batch != nil {
+		h.serveBatch(w, ctx, handlerFunc, batch, mediaType)
+		return
+	}
+
 	response := handlerFunc(&request)
 
-	w.Header().Set("Content-Type", "application/json")
-	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", h.Indent)
-	err := encoder.Encode(response)
+	writeResponseHeader(ctx, w)
+	w.Header().Set("Content-Type", mediaType)
+	// clients that accept the graphql response media type get a 400 for requests that could not be executed.
+	if mediaType == graphQLResponseMediaType && len(response.Data) == 0 && len(response.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	err := h.writeResponse(w, response)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	io.WriteString(w, "\n")
+}
+
+func decodeExtensions(r *http.Request, request *graphql.Request) error {
+	extensions := r.URL.Query().Get("extensions")
+	if extensions == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(extensions), &request.Extensions)
+}
+
+// graphQLResponseMediaType is the response media type defined by the GraphQL over HTTP spec.
+const graphQLResponseMediaType = "application/graphql-response+json"
+
+// negotiateMediaType picks the media type of json responses using the quality values of the Accept
+// header.  Clients that don't send an Accept header get application/json.
+func negotiateMediaType(r *http.Request) (string, bool) {
+	values := r.Header["Accept"]
+	if len(values) == 0 {
+		return "application/json", true
+	}
+	result := ""
+	resultQuality := 0.0
+	for _, accept := range values {
+		for _, accepted := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+			if err != nil {
+				continue
+			}
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				quality, err = strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+			}
+			switch mediaType {
+			case graphQLResponseMediaType:
+			case "application/json", "application/*", "*/*":
+				mediaType = "application/json"
+			default:
+				continue
+			}
+			if quality > resultQuality {
+				result = mediaType
+				resultQuality = quality
+			}
+		}
+	}
+	return result, result != ""
+}
+
+// isMutation returns true if the request selects a mutation.  Queries that fail to parse are
+// left for the handler func to report.
+func isMutation(request *graphql.Request) bool {
+	return operationType(request) == schema.Mutation
+}
+
+// operationType returns the type of the operation selected by the request, or InvalidOperation
+// when the query is missing or fails to parse.
+func operationType(request *graphql.Request) schema.OperationType {
+	if request.Query == "" {
+		return schema.InvalidOperation
+	}
+	doc := schema.QueryDocument{}
+	defer doc.Close()
+	if err := doc.Parse(request.Query); err != nil {
+		return schema.InvalidOperation
+	}
+	op, err := doc.GetOperation(request.OperationName)
+	if err != nil {
+		return schema.InvalidOperation
+	}
+	return op.Type
+}
+
+func accepts(r *http.Request, mediaType string) bool {
+	for _, accept := range r.Header["Accept"] {
+		for _, accepted := range strings.Split(accept, ",") {
+			if strings.HasPrefix(strings.TrimSpace(accepted), mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serveMultipart writes each response of the stream as a part of a multipart/mixed response,
+// so that the @defer and @stream payloads are delivered as they are produced.  The stream gets
+// canceled with cancel when a part can't be written.
+func (h *Handler) serveMultipart(w http.ResponseWriter, stream graphql.ResponseStream, cancel context.CancelFunc) {
+	w.Header().Set("Content-Type", `multipart/mixed; boundary="-"`)
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	for response := range stream {
+		io.WriteString(w, "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n")
+		if err := h.writeResponse(w, response); err != nil {
+			drain(cancel, stream)
+			return
+		}
+		io.WriteString(w, "\n")
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	io.WriteString(w, "\r\n-----\r\n")
+}
+
+// writeResponse writes the response as json.  With StreamResponseData, the already serialized data
+// is copied to the writer and only the remaining fields of the response get encoded.
+func (h *Handler) writeResponse(w io.Writer, response *graphql.Response) error {
+	if !h.StreamResponseData || h.Indent != "" || len(response.Data) == 0 {
+		payload, err := h.marshal(response)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(payload)
+		return err
+	}
+
+	rest := *response
+	rest.Data = nil
+	payload, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+	// data is the first field of the response object.
+	if _, err := io.WriteString(w, `{"data":`); err != nil {
+		return err
+	}
+	if _, err := w.Write(response.Data); err != nil {
+		return err
+	}
+	if len(payload) > 2 {
+		_, err = io.WriteString(w, ","+string(payload[1:]))
+	} else {
+		_, err = io.WriteString(w, "}")
+	}
+	return err
 }
@@ -1,10 +1,20 @@
 package httpgql_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/chirino/graphql/httpgql"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +43,28 @@ func TestEngineAPIServeHTTP(t *testing.T) {
 `, w.Body.String())
 }
 
+func TestServeHTTPMultipart(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ hero { name ... @defer { id } } }"}`))
+	r.Header.Set("Accept", "multipart/mixed, application/json")
+
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `multipart/mixed; boundary="-"`, w.Header().Get("Content-Type"))
+	assert.Equal(t, "\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"+
+		`{"data":{"hero":{"name":"R2-D2"}},"hasNext":true}`+"\n"+
+		"\r\n---\r\nContent-Type: application/json; charset=utf-8\r\n\r\n"+
+		`{"data":{"id":"2001"},"path":["hero"],"hasNext":false}`+"\n"+
+		"\r\n-----\r\n", w.Body.String())
+}
+
 func TestClientServeGraphQL(t *testing.T) {
 
 	s := httptest.NewServer(&httpgql.Handler{
@@ -47,3 +79,316 @@ func TestClientServeGraphQL(t *testing.T) {
 	response := client.ServeGraphQL(&graphql.Request{Query: "{hello}"})
 	assert.Equal(t, `{"hello":"world"}`, string(response.Data))
 }
+
+func TestClientPersistedQueries(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+	engine.PersistedQueries = graphql.NewPersistedQueryLRU(10)
+
+	requests := []string{}
+	h := &httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		h.ServeHTTP(w, r)
+	}))
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.UsePersistedQueries = true
+
+	query := "{ hero { name } }"
+	hash := graphql.PersistedQueryHash(query)
+
+	response := client.ServeGraphQL(&graphql.Request{Query: query})
+	require.NoError(t, response.Error())
+	assert.Equal(t, `{"hero":{"name":"R2-D2"}}`, string(response.Data))
+	require.Len(t, requests, 2)
+	assert.NotContains(t, requests[0], `"query"`)
+	assert.Contains(t, requests[1], `"query"`)
+
+	// the server now knows the hash, so only one request is needed.
+	response = client.ServeGraphQL(&graphql.Request{Query: query})
+	require.NoError(t, response.Error())
+	assert.Equal(t, `{"hero":{"name":"R2-D2"}}`, string(response.Data))
+	require.Len(t, requests, 3)
+	assert.NotContains(t, requests[2], `"query"`)
+
+	// hash only GET requests work too.
+	extensions := url.QueryEscape(`{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}`)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/graphql?extensions="+extensions, nil))
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}
+`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"unknown"}}}`)))
+	assert.Equal(t, `{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}
+`, w.Body.String())
+}
+
+func TestServeHTTPBatch(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`[{"query":"{ hero { name } }"}, {"query":"{ hero(episode: EMPIRE) { name } }"}, {"query":"{ bad }"}]`))
+
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, BatchConcurrency: 2}
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `[{"data":{"hero":{"name":"R2-D2"}}},{"data":{"hero":{"name":"Luke Skywalker"}}},{"errors":[{"message":"Cannot query field \"bad\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}]
+`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`[{"query":"{ hero { name } }"}, {"query":"{ hero { name } }"}]`))
+	h.MaxBatchSize = 1
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 400, w.Code)
+}
+
+func TestClientBatching(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+
+	batches := int32(0)
+	h := &httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&batches, 1)
+		h.ServeHTTP(w, r)
+	}))
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.BatchInterval = 50 * time.Millisecond
+
+	episodes := []string{"NEWHOPE", "EMPIRE", "JEDI"}
+	responses := make([]*graphql.Response, len(episodes))
+	wg := sync.WaitGroup{}
+	for i, episode := range episodes {
+		wg.Add(1)
+		go func(i int, episode string) {
+			defer wg.Done()
+			responses[i] = client.ServeGraphQL(&graphql.Request{Query: "{ hero(episode: " + episode + ") { name } }"})
+		}(i, episode)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&batches))
+	assert.Equal(t, `{"hero":{"name":"R2-D2"}}`, string(responses[0].Data))
+	assert.Equal(t, `{"hero":{"name":"Luke Skywalker"}}`, string(responses[1].Data))
+	assert.Equal(t, `{"hero":{"name":"R2-D2"}}`, string(responses[2].Data))
+}
+
+func TestClientBatchingMaxBatchSize(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+
+	batches := int32(0)
+	h := &httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&batches, 1)
+		h.ServeHTTP(w, r)
+	}))
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.BatchInterval = 300 * time.Millisecond
+	client.MaxBatchSize = 2
+
+	// a full batch is sent without waiting for the interval.
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := client.ServeGraphQL(&graphql.Request{Query: "{ hero { name } }"})
+			assert.NoError(t, response.Error())
+		}()
+	}
+	wg.Wait()
+	assert.Less(t, int64(time.Since(start)), int64(client.BatchInterval))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&batches))
+
+	// the timer of the full batch must not flush the next batch early.
+	time.Sleep(100 * time.Millisecond)
+	start = time.Now()
+	response := client.ServeGraphQL(&graphql.Request{Query: "{ hero { name } }"})
+	require.NoError(t, response.Error())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(client.BatchInterval))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&batches))
+}
+
+func TestServeHTTPGraphQLOverHTTP(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ hero { name } }"}`))
+	r.Header.Set("Accept", "application/graphql-response+json, application/json;q=0.9")
+	w := serve(r)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/graphql-response+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}`+"\n", w.Body.String())
+
+	// validation errors get a 400 with the graphql response media type..
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ bad }"}`))
+	r.Header.Set("Accept", "application/graphql-response+json")
+	w = serve(r)
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, "application/graphql-response+json", w.Header().Get("Content-Type"))
+
+	// but a 200 with application/json
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ bad }"}`))
+	r.Header.Set("Accept", "application/json")
+	w = serve(r)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ hero { name } }"}`))
+	r.Header.Set("Accept", "text/html")
+	assert.Equal(t, 406, serve(r).Code)
+
+	r = httptest.NewRequest("POST", "/?operationName=Hero", strings.NewReader(`query Hero { hero { name } }`))
+	r.Header.Set("Content-Type", "application/graphql")
+	w = serve(r)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}`+"\n", w.Body.String())
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`query=%7Bhero%7D`))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.Equal(t, 415, serve(r).Code)
+
+	r = httptest.NewRequest("GET", "/?query="+url.QueryEscape(`mutation { createReview(episode: JEDI, review: {stars: 5}) { stars } }`), nil)
+	w = serve(r)
+	assert.Equal(t, 405, w.Code)
+	assert.Equal(t, "POST", w.Header().Get("Allow"))
+
+	r = httptest.NewRequest("PUT", "/", strings.NewReader(`{"query":"{ hero { name } }"}`))
+	w = serve(r)
+	assert.Equal(t, 405, w.Code)
+	assert.Equal(t, "GET, POST", w.Header().Get("Allow"))
+}
+
+func TestServeHTTPCompression(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(starwars.Schema)
+	require.NoError(t, err)
+	engine.Root = &starwars.Resolver{}
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, Compression: true}
+
+	serve := func(acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ hero { name } }"}`))
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("deflate;q=0.5, gzip")
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}`+"\n", string(body))
+
+	w = serve("br, deflate")
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+	reader2, err := zlib.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err = ioutil.ReadAll(reader2)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}`+"\n", string(body))
+
+	w = serve("identity")
+	assert.Equal(t, "", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, `{"data":{"hero":{"name":"R2-D2"}}}`+"\n", w.Body.String())
+}
+
+func TestServeHTTPStreamResponseData(t *testing.T) {
+	h := httpgql.Handler{
+		ServeGraphQL: func(request *graphql.Request) *graphql.Response {
+			response := graphql.NewResponse().AddError(errors.New("partial"))
+			response.Data = json.RawMessage(`{"html":"<b>"}`)
+			return response
+		},
+		StreamResponseData: true,
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{ html }"}`)))
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":{"html":"<b>"},"errors":[{"message":"partial"}]}`+"\n", w.Body.String())
+}
+
+type counter struct {
+	count int32
+}
+
+func (c *counter) Count() int32 {
+	return atomic.LoadInt32(&c.count)
+}
+
+func (c *counter) Increment() int32 {
+	return atomic.AddInt32(&c.count, 1)
+}
+
+func TestServeHTTPPersistedMutationGET(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(`
+schema {
+	query: Query
+	mutation: Mutation
+}
+type Query {
+	count: Int
+}
+type Mutation {
+	increment: Int
+}
+`)
+	require.NoError(t, err)
+	root := &counter{}
+	engine.Root = root
+	engine.PersistedQueries = graphql.NewPersistedQueryLRU(10)
+	h := &httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+
+	query := "mutation { increment }"
+	hash := graphql.PersistedQueryHash(query)
+	extensions := `{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}`
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query":"`+query+`","extensions":`+extensions+`}`)))
+	assert.Equal(t, `{"data":{"increment":1}}
+`, w.Body.String())
+
+	// the hash alone does not tell the handler it's a mutation, the engine has to reject it.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/graphql?extensions="+url.QueryEscape(extensions), nil))
+	assert.Equal(t, `{"errors":[{"message":"mutations can't be executed by read only requests"}]}
+`, w.Body.String())
+	assert.Equal(t, int32(1), root.Count())
+}
@@ -0,0 +1,107 @@
+package httpgql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/qerrors"
+)
+
+// serveGraphQLStreamSSE executes the request using a server-sent events response.  The
+// stream is closed when the server sends the complete event.
+func (client *Client) serveGraphQLStreamSSE(request *graphql.Request) graphql.ResponseStream {
+	c := client.HTTPClient
+	if c == nil {
+		c = &http.Client{}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return graphql.NewErrStream(err)
+	}
+
+	req, err := http.NewRequestWithContext(request.GetContext(), http.MethodPost, client.URL, bytes.NewReader(body))
+	if err != nil {
+		return graphql.NewErrStream(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Content-Type", "application/json")
+
+	for k, h := range client.RequestHeader {
+		req.Header[k] = h
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return graphql.NewErrStream(err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "text/event-stream") {
+		defer resp.Body.Close()
+		if strings.HasPrefix(contentType, "application/json") {
+			response := graphql.NewResponse()
+			err = json.NewDecoder(resp.Body).Decode(&response)
+			if err != nil {
+				return graphql.NewErrStream(err)
+			}
+			responses := make(chan *graphql.Response, 1)
+			responses <- response
+			close(responses)
+			return responses
+		}
+		preview, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return graphql.NewErrStream(qerrors.Errorf("invalid content type: %s", contentType).WithCause(errors.New(string(preview))))
+	}
+
+	responses := make(chan *graphql.Response, 1)
+	go func() {
+		defer func() {
+			resp.Body.Close()
+			close(responses)
+		}()
+
+		reader := bufio.NewReader(resp.Body)
+		event := ""
+		data := []string{}
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				if err != io.EOF && request.GetContext().Err() == nil {
+					responses <- graphql.NewResponse().AddError(err)
+				}
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+
+			switch {
+			case line == "":
+				// a blank line dispatches the event.
+				switch event {
+				case "next":
+					response := graphql.NewResponse()
+					if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &response); err != nil {
+						response.AddError(err)
+					}
+					responses <- response
+				case "complete":
+					return
+				}
+				event = ""
+				data = data[:0]
+			case strings.HasPrefix(line, "event:"):
+				event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			}
+		}
+	}()
+	return responses
+}
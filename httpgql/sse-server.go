@@ -0,0 +1,74 @@
+package httpgql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chirino/graphql"
+)
+
+// serveEventStream writes each response of the stream as a server-sent `next` event followed by
+// a `complete` event once the stream ends.  The stream gets canceled when the client disconnects
+// since the request context is done, or with cancel when a response can't be written.
+func (h *Handler) serveEventStream(w http.ResponseWriter, stream graphql.ResponseStream, cancel context.CancelFunc) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	for response := range stream {
+		payload, err := h.marshal(response)
+		if err != nil {
+			drain(cancel, stream)
+			return
+		}
+		if err := writeEvent(w, "next", string(payload)); err != nil {
+			drain(cancel, stream)
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	writeEvent(w, "complete", "")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// drain cancels the request of the stream and discards the responses left, so that the goroutine
+// producing them is not left blocked on a send once they can't be written anymore.
+func drain(cancel context.CancelFunc, stream graphql.ResponseStream) {
+	cancel()
+	for range stream {
+	}
+}
+
+func (h *Handler) marshal(v interface{}) ([]byte, error) {
+	if h.Indent == "" {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", h.Indent)
+}
+
+func writeEvent(w io.Writer, event string, data string) error {
+	var sb strings.Builder
+	sb.WriteString("event: ")
+	sb.WriteString(event)
+	sb.WriteString("\n")
+	// every line of the data needs a data field.
+	for _, line := range strings.Split(data, "\n") {
+		sb.WriteString("data:")
+		if line != "" {
+			sb.WriteString(" ")
+			sb.WriteString(line)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
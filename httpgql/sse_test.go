@@ -0,0 +1,112 @@
+package httpgql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/httpgql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHTTPEventStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"{hello}"}`))
+	r.Header.Set("Accept", "text/event-stream")
+
+	h := httpgql.Handler{ServeGraphQLStream: helloWorldStream}
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "event: next\ndata: {\"data\":{\"hello\":\"world\"}}\n\nevent: complete\ndata:\n\n", w.Body.String())
+}
+
+func TestClientServerSentEvents(t *testing.T) {
+	canceled := make(chan struct{})
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			result := make(chan *graphql.Response, 1)
+			go func() {
+				defer close(result)
+				for i := 0; ; i++ {
+					select {
+					case result <- &graphql.Response{Data: json.RawMessage(fmt.Sprintf(`{"count":%d}`, i))}:
+					case <-request.Context.Done():
+						close(canceled)
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+			return result
+		},
+	})
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.UseServerSentEvents = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rs := client.ServeGraphQLStream(&graphql.Request{Context: ctx, Query: "subscription {count}"})
+	response := <-rs
+	require.NotNil(t, response)
+	assert.Equal(t, `{"count":0}`, string(response.Data))
+	response = <-rs
+	require.NotNil(t, response)
+	assert.Equal(t, `{"count":1}`, string(response.Data))
+
+	// canceling the request closes the stream on both sides.
+	cancel()
+	for range rs {
+	}
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server stream was not canceled")
+	}
+}
+
+func TestServeHTTPEventStreamClientGone(t *testing.T) {
+	exited := make(chan struct{})
+	data := json.RawMessage(`"` + strings.Repeat("x", 64*1024) + `"`)
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			result := make(chan *graphql.Response)
+			go func() {
+				defer close(exited)
+				defer close(result)
+				// like the engine, the responses are sent without watching the context.
+				for request.Context.Err() == nil {
+					result <- &graphql.Response{Data: data}
+				}
+			}()
+			return result
+		},
+	})
+	defer s.Close()
+
+	r, err := http.NewRequest("POST", s.URL, strings.NewReader(`{"query":"subscription {hello}"}`))
+	require.NoError(t, err)
+	r.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(r)
+	require.NoError(t, err)
+	_, err = resp.Body.Read(make([]byte, 1024))
+	require.NoError(t, err)
+	// the client goes away in the middle of the stream.
+	resp.Body.Close()
+	http.DefaultClient.CloseIdleConnections()
+
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream goroutine did not exit")
+	}
+}
@@ -0,0 +1,124 @@
+package httpgql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/customtypes"
+)
+
+// encodeRequest encodes the request as json, or as a GraphQL multipart request when
+// the variables hold customtypes.Upload values.
+func encodeRequest(request *graphql.Request) (body io.Reader, contentType string, err error) {
+	uploads := map[string]*customtypes.Upload{}
+	variables := extractUploads(request.Variables, "variables", uploads)
+	if len(uploads) == 0 {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return nil, "", err
+		}
+		return bytes.NewReader(data), "application/json", nil
+	}
+
+	operations := *request
+	operations.Variables = variables
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, "", err
+	}
+
+	paths := make([]string, 0, len(uploads))
+	for path := range uploads {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// stream the files to the server instead of buffering them.
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, data, paths, uploads))
+	}()
+	return pr, mw.FormDataContentType(), nil
+}
+
+func writeMultipart(mw *multipart.Writer, operations []byte, paths []string, uploads map[string]*customtypes.Upload) error {
+	if err := mw.WriteField("operations", string(operations)); err != nil {
+		return err
+	}
+	mapping := map[string][]string{}
+	for i, path := range paths {
+		mapping[strconv.Itoa(i)] = []string{path}
+	}
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	if err := mw.WriteField("map", string(data)); err != nil {
+		return err
+	}
+
+	for i, path := range paths {
+		upload := uploads[path]
+		if upload.File == nil {
+			return fmt.Errorf("upload at %s has no file", path)
+		}
+		contentType := upload.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%d"; filename="%s"`, i, quoteEscaper.Replace(upload.Filename)))
+		header.Set("Content-Type", contentType)
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, upload.File); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// extractUploads returns a copy of the value with the uploads replaced by nulls, the
+// uploads are collected by their path in the request.
+func extractUploads(value interface{}, path string, uploads map[string]*customtypes.Upload) interface{} {
+	switch value := value.(type) {
+	case *customtypes.Upload:
+		uploads[path] = value
+		return nil
+	case customtypes.Upload:
+		uploads[path] = &value
+		return nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = extractUploads(v, path+"."+k, uploads)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = extractUploads(v, path+"."+strconv.Itoa(i), uploads)
+		}
+		return result
+	case []*customtypes.Upload:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = extractUploads(v, path+"."+strconv.Itoa(i), uploads)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,280 @@
+package httpgql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/customtypes"
+)
+
+// multipartMaxMemory is how much of the uploaded files are held in memory, the rest is stored in temporary files.
+const multipartMaxMemory = 32 << 20
+
+// limitedBody fails reads once more than limit bytes have been read.
+type limitedBody struct {
+	io.ReadCloser
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		b.exceeded = true
+		return n, fmt.Errorf("request body exceeds the max upload size of %d bytes", b.limit)
+	}
+	return n, err
+}
+
+// multipartForm holds the fields and files of a multipart request.
+type multipartForm struct {
+	values   map[string]string
+	files    map[string]*multipartFile
+	tmpfiles []string
+}
+
+// multipartFile is an uploaded file, it's kept in memory or in a temporary file once the uploaded
+// files use more than multipartMaxMemory.
+type multipartFile struct {
+	filename    string
+	contentType string
+	size        int64
+	content     []byte
+	tmpfile     string
+}
+
+func (f *multipartFile) open() (io.ReadCloser, error) {
+	if f.tmpfile != "" {
+		return os.Open(f.tmpfile)
+	}
+	return ioutil.NopCloser(bytes.NewReader(f.content)), nil
+}
+
+func (form *multipartForm) removeAll() {
+	for _, tmpfile := range form.tmpfiles {
+		os.Remove(tmpfile)
+	}
+}
+
+// decodeMultipart decodes a request that follows the GraphQL multipart request spec, see:
+// https://github.com/jaydenseric/graphql-multipart-request-spec
+// The uploaded files are set as *customtypes.Upload values in the request variables.
+func (h *Handler) decodeMultipart(r *http.Request, request *graphql.Request) (batch []graphql.Request, closeFiles func(), status int, err error) {
+	closeFiles = func() {}
+
+	limit := h.MaxUploadSizeBytes
+	if limit <= 0 {
+		limit = h.MaxRequestSizeBytes
+	}
+	var body *limitedBody
+	if limit > 0 {
+		body = &limitedBody{ReadCloser: r.Body, limit: limit}
+		r.Body = body
+	}
+	form, status, err := h.readMultipartForm(r)
+	if form != nil {
+		closeFiles = form.removeAll
+	}
+	// the reader may not report the read error if the limit was hit after the last part.
+	if body != nil && body.exceeded {
+		return nil, closeFiles, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds the max upload size of %d bytes", limit)
+	}
+	if err != nil {
+		return nil, closeFiles, status, err
+	}
+	var opened []io.ReadCloser
+	closeFiles = func() {
+		for _, file := range opened {
+			file.Close()
+		}
+		form.removeAll()
+	}
+
+	operations, ok := form.values["operations"]
+	if !ok {
+		return nil, closeFiles, http.StatusBadRequest, fmt.Errorf("missing multipart field: operations")
+	}
+	if h.MaxRequestSizeBytes > 0 && int64(len(operations)) > h.MaxRequestSizeBytes {
+		return nil, closeFiles, http.StatusRequestEntityTooLarge, fmt.Errorf("operations exceed the max request size of %d bytes", h.MaxRequestSizeBytes)
+	}
+	batch, err = decodeOperations([]byte(operations), request)
+	if err != nil {
+		return nil, closeFiles, http.StatusBadRequest, err
+	}
+
+	mapping := map[string][]string{}
+	if value, ok := form.values["map"]; ok {
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			return nil, closeFiles, http.StatusBadRequest, err
+		}
+	}
+
+	for key, paths := range mapping {
+		header := form.files[key]
+		if header == nil {
+			return nil, closeFiles, http.StatusBadRequest, fmt.Errorf("missing multipart file: %s", key)
+		}
+		file, err := header.open()
+		if err != nil {
+			return nil, closeFiles, http.StatusBadRequest, err
+		}
+		opened = append(opened, file)
+
+		upload := &customtypes.Upload{
+			File:        file,
+			Filename:    header.filename,
+			ContentType: header.contentType,
+			Size:        header.size,
+		}
+		for _, path := range paths {
+			if err := setUpload(request, batch, path, upload); err != nil {
+				return nil, closeFiles, http.StatusBadRequest, err
+			}
+		}
+	}
+	return batch, closeFiles, http.StatusOK, nil
+}
+
+// readMultipartForm reads the parts of a multipart request.  Files are checked against MaxFileSizeBytes
+// while they are read, so oversized files are never fully stored.
+func (h *Handler) readMultipartForm(r *http.Request) (*multipartForm, int, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	form := &multipartForm{values: map[string]string{}, files: map[string]*multipartFile{}}
+	memory := int64(multipartMaxMemory)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return form, http.StatusOK, nil
+		}
+		if err != nil {
+			return form, http.StatusBadRequest, err
+		}
+		name := part.FormName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			value, err := ioutil.ReadAll(io.LimitReader(part, memory+1))
+			if err != nil {
+				return form, http.StatusBadRequest, err
+			}
+			if int64(len(value)) > memory {
+				return form, http.StatusRequestEntityTooLarge, fmt.Errorf("multipart field %s is too large", name)
+			}
+			memory -= int64(len(value))
+			if _, ok := form.values[name]; !ok {
+				form.values[name] = string(value)
+			}
+			continue
+		}
+
+		file, status, err := h.readMultipartFile(form, part, &memory)
+		if err != nil {
+			return form, status, err
+		}
+		if form.files[name] == nil {
+			form.files[name] = file
+		}
+	}
+}
+
+func (h *Handler) readMultipartFile(form *multipartForm, part *multipart.Part, memory *int64) (*multipartFile, int, error) {
+	file := &multipartFile{filename: part.FileName(), contentType: part.Header.Get("Content-Type")}
+	reader := io.Reader(part)
+	if h.MaxFileSizeBytes > 0 {
+		// one byte more than the limit is enough to know that the file is too large.
+		reader = io.LimitReader(part, h.MaxFileSizeBytes+1)
+	}
+
+	buffer := &bytes.Buffer{}
+	size, err := io.CopyN(buffer, reader, *memory+1)
+	if err != nil && err != io.EOF {
+		return nil, http.StatusBadRequest, err
+	}
+	if size <= *memory {
+		file.content = buffer.Bytes()
+		*memory -= size
+	} else {
+		tmpfile, err := ioutil.TempFile("", "graphql-upload-")
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		file.tmpfile = tmpfile.Name()
+		form.tmpfiles = append(form.tmpfiles, file.tmpfile)
+		size, err = io.Copy(tmpfile, io.MultiReader(buffer, reader))
+		if closeErr := tmpfile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	file.size = size
+
+	if h.MaxFileSizeBytes > 0 && size > h.MaxFileSizeBytes {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("file %s exceeds the max file size of %d bytes", file.filename, h.MaxFileSizeBytes)
+	}
+	return file, http.StatusOK, nil
+}
+
+// setUpload replaces the value found at a path like `variables.files.0` with the upload.  The paths
+// of batched operations start with the index of the operation, like `0.variables.file`.
+func setUpload(request *graphql.Request, batch []graphql.Request, path string, upload *customtypes.Upload) error {
+	segments := strings.Split(path, ".")
+	if batch != nil && len(segments) > 0 {
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(batch) {
+			return fmt.Errorf("invalid multipart map path: %s", path)
+		}
+		request = &batch[index]
+		segments = segments[1:]
+	}
+	if len(segments) < 2 || segments[0] != "variables" {
+		return fmt.Errorf("invalid multipart map path: %s", path)
+	}
+
+	var parent interface{} = request.Variables
+	for i, segment := range segments[1:] {
+		last := i == len(segments)-2
+		switch value := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := value[segment]; !ok {
+				return fmt.Errorf("invalid multipart map path: %s", path)
+			}
+			if last {
+				value[segment] = upload
+			} else {
+				parent = value[segment]
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return fmt.Errorf("invalid multipart map path: %s", path)
+			}
+			if last {
+				value[index] = upload
+			} else {
+				parent = value[index]
+			}
+		default:
+			return fmt.Errorf("invalid multipart map path: %s", path)
+		}
+	}
+	return nil
+}
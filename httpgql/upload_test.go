@@ -0,0 +1,184 @@
+package httpgql_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/customtypes"
+	"github.com/chirino/graphql/httpgql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type uploadResolver struct{}
+
+func (uploadResolver) Hello() string {
+	return "world"
+}
+
+func (uploadResolver) Upload(args struct{ File customtypes.Upload }) (string, error) {
+	data, err := ioutil.ReadAll(args.File.File)
+	return args.File.Filename + ":" + args.File.ContentType + ":" + string(data), err
+}
+
+func (uploadResolver) UploadMany(args struct{ Files []customtypes.Upload }) []string {
+	var result []string
+	for _, file := range args.Files {
+		data, _ := ioutil.ReadAll(file.File)
+		result = append(result, file.Filename+":"+string(data))
+	}
+	return result
+}
+
+func newUploadEngine(t *testing.T) *graphql.Engine {
+	engine := graphql.New()
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+			mutation: Query
+		}
+		scalar Upload
+		type Query {
+			hello: String
+			upload(file: Upload!): String
+			uploadMany(files: [Upload!]!): [String]
+		}
+	`)
+	require.NoError(t, err)
+	engine.Root = uploadResolver{}
+	return engine
+}
+
+func newUploadRequest(t *testing.T, operations string, mapping string, files map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("operations", operations))
+	require.NoError(t, mw.WriteField("map", mapping))
+	for name, content := range files {
+		part, err := mw.CreateFormFile(name, name+".txt")
+		require.NoError(t, err)
+		part.Write([]byte(content))
+	}
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestServeHTTPUpload(t *testing.T) {
+	engine := newUploadEngine(t)
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t,
+		`{"query":"mutation ($files: [Upload!]!) { uploadMany(files: $files) }","variables":{"files":[null,null]}}`,
+		`{"a":["variables.files.0"],"b":["variables.files.1"]}`,
+		map[string]string{"a": "hello", "b": "world"}))
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":{"uploadMany":["a.txt:hello","b.txt:world"]}}`+"\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t,
+		`{"query":"mutation ($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`,
+		`{"a":["variables.missing"]}`,
+		map[string]string{"a": "hello"}))
+	assert.Equal(t, 400, w.Code)
+}
+
+func TestServeHTTPUploadLimits(t *testing.T) {
+	engine := newUploadEngine(t)
+	operations := `{"query":"mutation ($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`
+	mapping := `{"a":["variables.file"]}`
+	files := map[string]string{"a": strings.Repeat("x", 1024)}
+
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxFileSizeBytes: 100}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 413, w.Code)
+
+	h = httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxUploadSizeBytes: 100}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 413, w.Code)
+
+	h = httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxRequestSizeBytes: 10}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 413, w.Code)
+
+	h = httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxFileSizeBytes: 2048, MaxUploadSizeBytes: 4096}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 200, w.Code)
+}
+
+// countingReader counts the bytes read from a request body.
+type countingReader struct {
+	reader io.Reader
+	read   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	return n, err
+}
+
+func TestServeHTTPUploadStreamingLimits(t *testing.T) {
+	engine := newUploadEngine(t)
+	operations := `{"query":"mutation ($file: Upload!) { upload(file: $file) }","variables":{"file":null}}`
+	mapping := `{"a":["variables.file"]}`
+	files := map[string]string{"a": strings.Repeat("x", 1024)}
+
+	// the max request size applies to multipart requests when the max upload size is not set.
+	h := httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxRequestSizeBytes: 500}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 413, w.Code)
+	assert.Equal(t, "request body exceeds the max upload size of 500 bytes\n", w.Body.String())
+
+	h = httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxRequestSizeBytes: 2048}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newUploadRequest(t, operations, mapping, files))
+	assert.Equal(t, 200, w.Code)
+
+	// large files are rejected without reading them completely.
+	size := 8 << 20
+	r := newUploadRequest(t, operations, mapping, map[string]string{"a": strings.Repeat("x", size)})
+	body := &countingReader{reader: r.Body}
+	r.Body = ioutil.NopCloser(body)
+	h = httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream, MaxFileSizeBytes: 100}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, 413, w.Code)
+	assert.Equal(t, "file a.txt exceeds the max file size of 100 bytes\n", w.Body.String())
+	assert.Less(t, body.read, int64(size/2))
+}
+
+func TestClientUpload(t *testing.T) {
+	engine := newUploadEngine(t)
+	s := httptest.NewServer(&httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream})
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	response := client.ServeGraphQL(&graphql.Request{
+		Query: "mutation ($file: Upload!) { upload(file: $file) }",
+		Variables: map[string]interface{}{
+			"file": &customtypes.Upload{
+				File:        strings.NewReader("hello world"),
+				Filename:    "hello.txt",
+				ContentType: "text/plain",
+			},
+		},
+	})
+	require.NoError(t, response.Error())
+	assert.Equal(t, `{"upload":"hello.txt:text/plain:hello world"}`, string(response.Data))
+}
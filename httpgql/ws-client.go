@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	url2 "net/url"
+	"strconv"
 	"time"
 
 	"github.com/chirino/graphql"
+	"github.com/chirino/graphql/schema"
 	"github.com/gorilla/websocket"
 )
 
@@ -16,6 +18,10 @@ func (client *Client) Exec(ctx context.Context, result interface{}, query string
 }
 
 func (client *Client) ServeGraphQLStream(request *graphql.Request) graphql.ResponseStream {
+	if client.UseServerSentEvents {
+		return client.serveGraphQLStreamSSE(request)
+	}
+
 	url := client.URL
 
 	client.mu.Lock()
@@ -27,39 +33,13 @@ func (client *Client) ServeGraphQLStream(request *graphql.Request) graphql.Respo
 			client:          client,
 			url:             url,
 			serviceCommands: make(chan interface{}),
+			closed:          make(chan struct{}),
 			streams:         map[int64]*wsOperation{},
 			idleFlag:        false,
 		}
-
-		wsUrl, err := ToWsURL(client.URL)
-		if err != nil {
-			return graphql.NewErrStream(err)
-		}
-
-		headers := client.RequestHeader.Clone()
-		headers.Set("Sec-WebSocket-Protocol", "graphql-ws")
-
-		c.websocket, _, err = websocket.DefaultDialer.Dial(wsUrl, headers)
-		if err != nil {
-			return graphql.NewErrStream(err)
-		}
-
-		err = c.websocket.WriteJSON(OperationMessage{Type: "connection_init"})
-		if err != nil {
-			c.websocket.Close()
-			return graphql.NewErrStream(err)
-		}
-
-		op := OperationMessage{}
-		err = c.websocket.ReadJSON(&op)
-		if err != nil {
-			c.websocket.Close()
+		if err := c.connect(); err != nil {
 			return graphql.NewErrStream(err)
 		}
-		if op.Type != "connection_ack" {
-			c.websocket.Close()
-			return graphql.NewErrStream(fmt.Errorf("protocol violation: expected an init message, but received: %v\n", op.Type))
-		}
 		go service(c)
 
 		client.mu.Lock()
@@ -84,11 +64,44 @@ func ToWsURL(url string) (string, error) {
 	return wsUrl, nil
 }
 
+// ReconnectPolicy configures how httpgql.Client reconnects websocket connections that fail while they
+// have active streams.  The delay between attempts starts at InitialBackoff and doubles after every
+// failed attempt up to MaxBackoff.
+type ReconnectPolicy struct {
+	// InitialBackoff defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff defaults to 30 seconds.
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of attempts before giving up, 0 means no limit.
+	MaxAttempts int
+}
+
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	for i := 0; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
 type wsConnection struct {
 	client          *Client
 	url             string
 	serviceCommands chan interface{}
-	websocket       *websocket.Conn
+	// closed is closed once the service goroutine exits.
+	closed    chan struct{}
+	websocket *websocket.Conn
+	protocol  string
 
 	// these fields should only be mutated by the service* methods.
 	nextStreamId int64
@@ -97,9 +110,80 @@ type wsConnection struct {
 	err          error
 }
 
+// messageType returns the name of a message in the protocol used by the connection.
+func (c *wsConnection) messageType(legacy string, transport string) string {
+	if c.protocol == GraphQLTransportWS {
+		return transport
+	}
+	return legacy
+}
+
+// messageId encodes a stream id, the graphql-transport-ws protocol uses string ids.
+func (c *wsConnection) messageId(id int64) interface{} {
+	if c.protocol == GraphQLTransportWS {
+		return strconv.FormatInt(id, 10)
+	}
+	return id
+}
+
+func parseMessageId(id interface{}) (int64, bool) {
+	switch id := id.(type) {
+	case float64:
+		return int64(id), true
+	case string:
+		result, err := strconv.ParseInt(id, 10, 64)
+		return result, err == nil
+	}
+	return 0, false
+}
+
+// connect opens the websocket and performs the connection_init handshake.
+func (c *wsConnection) connect() error {
+	wsUrl, err := ToWsURL(c.url)
+	if err != nil {
+		return err
+	}
+
+	// let the server pick the protocol, servers that don't negotiate speak the legacy protocol.
+	headers := c.client.RequestHeader.Clone()
+	headers.Set("Sec-WebSocket-Protocol", GraphQLTransportWS+", "+GraphQLWS)
+
+	ws, _, err := websocket.DefaultDialer.Dial(wsUrl, headers)
+	if err != nil {
+		return err
+	}
+
+	err = ws.WriteJSON(OperationMessage{Type: "connection_init", Payload: c.client.ConnectionInitPayload})
+	if err != nil {
+		ws.Close()
+		return err
+	}
+
+	op := OperationMessage{}
+	err = ws.ReadJSON(&op)
+	if err != nil {
+		ws.Close()
+		return err
+	}
+	if op.Type == "connection_error" {
+		ws.Close()
+		return fmt.Errorf("graphql connection error: %s", string(op.Payload))
+	}
+	if op.Type != "connection_ack" {
+		ws.Close()
+		return fmt.Errorf("protocol violation: expected an init message, but received: %v\n", op.Type)
+	}
+
+	c.websocket = ws
+	c.protocol = ws.Subprotocol()
+	return nil
+}
+
 func (c *wsConnection) Close() {
-	c.serviceCommands <- "close"
-	close(c.serviceCommands)
+	select {
+	case c.serviceCommands <- "close":
+	case <-c.closed:
+	}
 }
 
 func (c *wsConnection) ServeGraphQLStream(request *graphql.Request) graphql.ResponseStream {
@@ -107,8 +191,32 @@ func (c *wsConnection) ServeGraphQLStream(request *graphql.Request) graphql.Resp
 		request:         request,
 		responseChannel: make(chan *graphql.Response, 1),
 	}
-	c.serviceCommands <- stream
-	return stream.responseChannel
+	select {
+	case c.serviceCommands <- stream:
+		return stream.responseChannel
+	case <-c.closed:
+		return graphql.NewErrStream(fmt.Errorf("websocket connection closed: %v", c.err))
+	}
+}
+
+// serviceReader is the read side goroutine of a websocket.
+func serviceReader(c *wsConnection, ws *websocket.Conn) {
+	for {
+		o := OperationMessage{}
+		err := ws.ReadJSON(&o)
+		var command interface{} = o
+		if err != nil {
+			command = err
+		}
+		select {
+		case c.serviceCommands <- command:
+		case <-c.closed:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
 }
 
 func service(c *wsConnection) {
@@ -116,31 +224,16 @@ func service(c *wsConnection) {
 	defer func() {
 		c.websocket.Close()
 		ticker.Stop()
-	}()
-
-	go func() {
-
-		// This is the read side goroutine
-		defer func() {
-			close(c.serviceCommands)
-			c.client.mu.Lock()
-			if c.client.connections[c.url] == c {
-				delete(c.client.connections, c.url)
-			}
-			c.client.mu.Unlock()
-		}()
-
-		for {
-			o := OperationMessage{}
-			err := c.websocket.ReadJSON(&o)
-			if err != nil {
-				c.serviceCommands <- err
-				return
-			}
-			c.serviceCommands <- o
+		c.client.mu.Lock()
+		if c.client.connections[c.url] == c {
+			delete(c.client.connections, c.url)
 		}
+		c.client.mu.Unlock()
+		close(c.closed)
 	}()
 
+	go serviceReader(c, c.websocket)
+
 	for {
 		select {
 		case command := <-c.serviceCommands:
@@ -155,6 +248,9 @@ func service(c *wsConnection) {
 				c.idleFlag = false
 				serviceRead(c, command)
 			case error:
+				if serviceReconnect(c, command) {
+					continue
+				}
 				serviceError(c, command)
 				return
 			case string:
@@ -191,9 +287,14 @@ func service(c *wsConnection) {
 }
 
 func serviceClose(c *wsConnection) {
-	err := c.websocket.WriteJSON(OperationMessage{
-		Type: "connection_terminate",
-	})
+	var err error
+	if c.protocol == GraphQLTransportWS {
+		err = c.websocket.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	} else {
+		err = c.websocket.WriteJSON(OperationMessage{
+			Type: "connection_terminate",
+		})
+	}
 	if err != nil {
 		serviceError(c, err)
 		return
@@ -208,31 +309,121 @@ func serviceClose(c *wsConnection) {
 type wsOperation struct {
 	id              int64
 	request         *graphql.Request
+	payload         json.RawMessage
 	responseChannel chan *graphql.Response
+	// subscription is set for subscription operations, they are the only ones restarted on reconnect
+	// once they have been sent, since restarting a query or mutation could execute it twice.
+	subscription bool
+	sent         bool
 }
 
 func serviceOpenOperation(c *wsConnection, stream *wsOperation) {
+	if !serviceRegisterOperation(c, stream) {
+		return
+	}
+	err := serviceSubscribe(c, stream)
+	if err != nil {
+		if c.client.Reconnect != nil {
+			// the reader will notice the broken connection, and the stream is restarted once reconnected.
+			return
+		}
+		delete(c.streams, stream.id)
+		stream.responseChannel <- graphql.NewResponse().AddError(err)
+		close(stream.responseChannel)
+	}
+}
+
+// serviceRegisterOperation assigns an id to the stream and keeps the request payload so that the
+// stream can be restarted if the connection gets reconnected.
+func serviceRegisterOperation(c *wsConnection, stream *wsOperation) bool {
 	payload, err := json.Marshal(stream.request)
 	if err != nil {
 		stream.responseChannel <- graphql.NewResponse().AddError(err)
 		close(stream.responseChannel)
-		return
+		return false
 	}
+	stream.subscription = operationType(stream.request) == schema.Subscription
 	stream.request = nil
-	streamId := c.nextStreamId
+	stream.payload = payload
+	stream.id = c.nextStreamId
 	c.nextStreamId += 1
-	err = c.websocket.WriteJSON(OperationMessage{
-		Id:      streamId,
-		Type:    "start",
-		Payload: payload,
+	c.streams[stream.id] = stream
+	return true
+}
+
+func serviceSubscribe(c *wsConnection, stream *wsOperation) error {
+	err := c.websocket.WriteJSON(OperationMessage{
+		Id:      c.messageId(stream.id),
+		Type:    c.messageType("start", "subscribe"),
+		Payload: stream.payload,
 	})
-	if err != nil {
-		stream.responseChannel <- graphql.NewResponse().AddError(err)
-		close(stream.responseChannel)
-		return
+	if err == nil {
+		stream.sent = true
+	}
+	return err
+}
+
+// serviceReconnect reopens a failed connection using the client's reconnect policy and restarts
+// the active subscriptions, so that they keep delivering responses on the same channels.  Queries
+// and mutations that were already sent fail with the connection error, the server may have executed them.
+func serviceReconnect(c *wsConnection, err error) bool {
+	policy := c.client.Reconnect
+	if policy == nil {
+		return false
+	}
+	for id, stream := range c.streams {
+		if stream.sent && !stream.subscription {
+			delete(c.streams, id)
+			stream.responseChannel <- graphql.NewResponse().AddError(err)
+			close(stream.responseChannel)
+		}
+	}
+	if len(c.streams) == 0 {
+		return false
+	}
+	c.websocket.Close()
+	for attempt := 0; policy.MaxAttempts <= 0 || attempt < policy.MaxAttempts; attempt++ {
+		if closed := serviceWait(c, policy.backoff(attempt)); closed {
+			return false
+		}
+		if err := c.connect(); err != nil {
+			continue
+		}
+		for _, stream := range c.streams {
+			// a failed write also fails the reader, which gets us to reconnect again.
+			serviceSubscribe(c, stream)
+		}
+		go serviceReader(c, c.websocket)
+		return true
+	}
+	return false
+}
+
+// serviceWait waits for the delay while the connection is down.  Streams opened in the meantime
+// get started once reconnected.  Returns true if the connection got closed.
+func serviceWait(c *wsConnection, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return false
+		case command := <-c.serviceCommands:
+			switch command := command.(type) {
+			case *wsOperation:
+				serviceRegisterOperation(c, command)
+			case closeOperation:
+				if c.streams[command.stream.id] != nil {
+					delete(c.streams, command.stream.id)
+					close(command.stream.responseChannel)
+				}
+			case string:
+				if command == "close" {
+					return true
+				}
+			}
+		}
 	}
-	stream.id = streamId
-	c.streams[streamId] = stream
 }
 
 type closeOperation struct {
@@ -246,11 +437,16 @@ func serviceCloseOperation(c *wsConnection, command closeOperation) {
 	}
 
 	// let the server know we want it to close the stream.
-	// it wil respond with a complete.
 	c.websocket.WriteJSON(OperationMessage{
-		Id:   id,
-		Type: "stop",
+		Id:   c.messageId(id),
+		Type: c.messageType("stop", "complete"),
 	})
+
+	// legacy servers respond with a complete, graphql-transport-ws servers don't.
+	if c.protocol == GraphQLTransportWS {
+		delete(c.streams, id)
+		close(command.stream.responseChannel)
+	}
 }
 
 func serviceError(c *wsConnection, err error) {
@@ -267,9 +463,9 @@ func serviceError(c *wsConnection, err error) {
 func serviceRead(c *wsConnection, command OperationMessage) {
 
 	switch command.Type {
-	case "data":
-		id := command.Id.(float64)
-		stream := serviceOperation(c, int64(id))
+	case "data", "next":
+		id, _ := parseMessageId(command.Id)
+		stream := serviceOperation(c, id)
 		if stream == nil {
 			return
 		}
@@ -281,14 +477,34 @@ func serviceRead(c *wsConnection, command OperationMessage) {
 		stream.responseChannel <- response
 
 	case "complete":
-		id := command.Id.(float64)
-		stream := serviceOperation(c, int64(id))
+		id, _ := parseMessageId(command.Id)
+		stream := serviceOperation(c, id)
 		if stream == nil {
 			return
 		}
 		delete(c.streams, stream.id)
 		close(stream.responseChannel)
 
+	case "error":
+		// the graphql-transport-ws protocol ends the stream with a list of errors.
+		id, _ := parseMessageId(command.Id)
+		stream := serviceOperation(c, id)
+		if stream == nil {
+			return
+		}
+		response := &graphql.Response{}
+		err := json.Unmarshal(command.Payload, &response.Errors)
+		if err != nil {
+			response.AddError(err)
+		}
+		stream.responseChannel <- response
+		delete(c.streams, stream.id)
+		close(stream.responseChannel)
+
+	case "ping":
+		c.websocket.WriteJSON(OperationMessage{Type: "pong"})
+	case "pong":
+
 	case "ka":
 		// keep alive.
 	case "connection_ack":
@@ -301,6 +517,10 @@ func serviceRead(c *wsConnection, command OperationMessage) {
 func serviceOperation(c *wsConnection, id int64) *wsOperation {
 	stream := c.streams[id]
 	if stream == nil {
+		if c.protocol == GraphQLTransportWS {
+			// messages that were in flight when we completed the stream.
+			return nil
+		}
 		serviceError(c, fmt.Errorf("invalid operation id received: %v", id))
 		return nil
 	}
@@ -4,132 +4,422 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/chirino/graphql"
 	"github.com/gorilla/websocket"
 )
 
+// The websocket subprotocols supported by Upgrade, in order of preference.
+const (
+	// GraphQLTransportWS is the protocol implemented by the graphql-ws library.
+	GraphQLTransportWS = "graphql-transport-ws"
+	// GraphQLWS is the legacy protocol implemented by the subscriptions-transport-ws library.
+	GraphQLWS = "graphql-ws"
+)
+
+// DefaultConnectionInitWaitTimeout is how long a graphql-transport-ws client has to send
+// the connection_init message before the server closes the socket.
+const DefaultConnectionInitWaitTimeout = 3 * time.Second
+
 type wsStream struct {
 	cancel    context.CancelFunc
 	responses graphql.ResponseStream
 }
 
+type wsSession struct {
+	handler              *Handler
+	ctx                  context.Context
+	conn                 *websocket.Conn
+	streamingHandlerFunc graphql.ServeGraphQLStreamFunc
+
+	mu        sync.Mutex
+	streams   map[interface{}]*wsStream
+	idleTimer *time.Timer
+	done      chan struct{}
+}
+
 func Upgrade(w http.ResponseWriter, r *http.Request, streamingHandlerFunc graphql.ServeGraphQLStreamFunc) {
+	(&Handler{}).upgrade(w, r, streamingHandlerFunc)
+}
+
+func (h *Handler) upgrade(w http.ResponseWriter, r *http.Request, streamingHandlerFunc graphql.ServeGraphQLStreamFunc) {
 
 	var upgrader = websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
+		Subprotocols:    []string{GraphQLTransportWS, GraphQLWS, "graphql-subscriptions"},
 	}
-	header := http.Header{}
-
-	subprotocol := r.Header.Get("Sec-WebSocket-Protocol")
-	switch subprotocol {
-	case "graphql-ws":
-		fallthrough
-	case "graphql-subscriptions":
-		header.Set("Sec-WebSocket-Protocol", subprotocol)
+	// without a CORS policy, the upgrader only accepts connections from the same host.
+	if h.CORS != nil {
+		upgrader.CheckOrigin = h.CORS.checkOrigin
 	}
 
-	mu := sync.Mutex{}
-	streams := map[interface{}]wsStream{}
-	conn, err := upgrader.Upgrade(w, r, header) // error ignored for sake of simplicity
+	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	// the upgrade response has been sent, so neither OnConnectionInit nor the operations can set response
+	// headers, the connection_init message is only read after the upgrade.
+	ctx := withHTTP(r.Context(), w, r, true)
+
+	s := &wsSession{
+		handler:              h,
+		ctx:                  ctx,
+		conn:                 conn,
+		streamingHandlerFunc: streamingHandlerFunc,
+		streams:              map[interface{}]*wsStream{},
+		done:                 make(chan struct{}),
+	}
 	defer func() {
-		mu.Lock()
-		for _, stream := range streams {
+		close(s.done)
+		s.mu.Lock()
+		if s.idleTimer != nil {
+			s.idleTimer.Stop()
+		}
+		for _, stream := range s.streams {
 			stream.cancel()
 		}
-		mu.Unlock()
+		s.mu.Unlock()
 		conn.Close()
 	}()
 
-	// websocket connections do not support concurrent write access.. protect with a mutex.
-	writeJSON := func(json interface{}) error {
-		mu.Lock()
-		err := conn.WriteJSON(json)
-		mu.Unlock()
+	if h.ReadTimeout > 0 {
+		s.extendReadDeadline()
+		conn.SetPongHandler(func(string) error {
+			s.extendReadDeadline()
+			return nil
+		})
+	}
+	if h.MaxIdleTime > 0 {
+		s.idleTimer = time.AfterFunc(h.MaxIdleTime, s.closeIfIdle)
+	}
+
+	if conn.Subprotocol() == GraphQLTransportWS {
+		initWaitTimeout := h.ConnectionInitWaitTimeout
+		if initWaitTimeout == 0 {
+			initWaitTimeout = DefaultConnectionInitWaitTimeout
+		}
+		s.serveGraphQLTransportWS(initWaitTimeout)
+	} else {
+		s.serveGraphQLWS()
+	}
+}
+
+// websocket connections do not support concurrent write access.. protect with a mutex.
+func (s *wsSession) writeJSON(json interface{}) error {
+	s.mu.Lock()
+	err := s.conn.WriteJSON(json)
+	s.mu.Unlock()
+	return err
+}
+
+func (s *wsSession) closeWithCode(code int, reason string) {
+	// control frames limit the size of the close reason.
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	s.mu.Lock()
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	s.mu.Unlock()
+}
+
+// extendReadDeadline gives the client another ReadTimeout to send us a message or a pong.
+func (s *wsSession) extendReadDeadline() {
+	if s.handler.ReadTimeout > 0 {
+		s.conn.SetReadDeadline(time.Now().Add(s.handler.ReadTimeout))
+	}
+}
+
+// closeIfIdle closes the connection if it has no active streams.  Closing the connection ends the
+// read loop which cancels all the streams of the connection.
+func (s *wsSession) closeIfIdle() {
+	s.mu.Lock()
+	idle := len(s.streams) == 0
+	s.mu.Unlock()
+	if idle {
+		s.closeWithCode(websocket.CloseNormalClosure, "Connection idle timeout")
+		s.conn.Close()
+	}
+}
+
+// connectionAck accepts the connection and starts sending keep-alive messages.
+func (s *wsSession) connectionAck(keepAliveType string) {
+	s.writeJSON(OperationMessage{Type: "connection_ack"})
+	if s.handler.KeepAliveInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.handler.KeepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// the websocket ping gets the client to send us a pong, which extends the read deadline.
+				err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.handler.KeepAliveInterval))
+				if err == nil {
+					err = s.writeJSON(OperationMessage{Type: keepAliveType})
+				}
+				if err != nil {
+					s.conn.Close()
+					return
+				}
+			case <-s.done:
+				return
+			}
+		}
+	}()
+}
+
+// connectionInit passes the connection_init payload to the OnConnectionInit hook.
+func (s *wsSession) connectionInit(payload json.RawMessage) error {
+	if s.handler.OnConnectionInit == nil {
+		return nil
+	}
+	ctx, err := s.handler.OnConnectionInit(s.ctx, payload)
+	if err != nil {
 		return err
 	}
+	if ctx != nil {
+		s.ctx = ctx
+	}
+	return nil
+}
+
+// resetIdleTimer restarts the idle timer once the connection has no more active streams.
+// The caller must hold the session lock.
+func (s *wsSession) resetIdleTimer() {
+	if s.idleTimer != nil && len(s.streams) == 0 {
+		s.idleTimer.Reset(s.handler.MaxIdleTime)
+	}
+}
+
+// start executes the request and sends its responses as nextType messages followed by a complete message.
+// When errorType is set, requests that fail before being executed, like the ones that don't validate, are
+// reported with a single errorType message instead.
+func (s *wsSession) start(id interface{}, request *graphql.Request, nextType string, errorType string) {
+	stream := &wsStream{}
+	request.Context, stream.cancel = context.WithCancel(s.ctx)
+	// @defer and @stream payloads are sent as additional data messages.
+	request.IncrementalDelivery = true
+	stream.responses = s.streamingHandlerFunc(request)
+
+	// save it.. so that client can later cancel it...
+	s.mu.Lock()
+	s.streams[id] = stream
+	s.mu.Unlock()
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+	}
+
+	// Start a goroutine ot handle the events....
+	go func() {
+		r, ok := <-stream.responses
+		// a single response without data holds the errors of a request that could not be executed.
+		if ok && errorType != "" && len(r.Data) == 0 && len(r.Errors) > 0 {
+			next, more := <-stream.responses
+			if !more {
+				s.fail(id, stream, errorType, r)
+				return
+			}
+			s.writeResponse(id, nextType, r)
+			r = next
+		}
+		for ok {
+			s.writeResponse(id, nextType, r)
+			r, ok = <-stream.responses
+		}
+
+		// the stream is no longer active if the client has already completed it.
+		if s.remove(id, stream) {
+			s.writeJSON(OperationMessage{Type: "complete", Id: id})
+		}
+		stream.cancel()
+	}()
+}
+
+func (s *wsSession) writeResponse(id interface{}, nextType string, r *graphql.Response) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal payload: %v\n", err))
+	}
+	s.writeJSON(OperationMessage{Type: nextType, Id: id, Payload: payload})
+}
+
+// fail sends the errors of a request that could not be executed, no complete message follows it.
+func (s *wsSession) fail(id interface{}, stream *wsStream, errorType string, r *graphql.Response) {
+	if s.remove(id, stream) {
+		payload, err := json.Marshal(r.Errors)
+		if err != nil {
+			panic(fmt.Sprintf("could not marshal payload: %v\n", err))
+		}
+		s.writeJSON(OperationMessage{Type: errorType, Id: id, Payload: payload})
+	}
+	stream.cancel()
+}
 
+// remove drops the stream from the active ones, it returns false if the client already completed it.
+func (s *wsSession) remove(id interface{}, stream *wsStream) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	active := s.streams[id] == stream
+	if active {
+		delete(s.streams, id)
+	}
+	s.resetIdleTimer()
+	return active
+}
+
+// serveGraphQLWS implements the legacy subscriptions-transport-ws protocol.
+func (s *wsSession) serveGraphQLWS() {
 	op := OperationMessage{}
-	err = conn.ReadJSON(&op)
+	err := s.conn.ReadJSON(&op)
 	if err != nil {
 		return
 	}
 	if op.Type != "connection_init" {
 		r := graphql.NewResponse().AddError(fmt.Errorf("protocol violation: expected an init message, but received: %v", op.Type))
 		payload, _ := json.Marshal(r)
-		writeJSON(OperationMessage{Type: "connection_error", Payload: payload})
+		s.writeJSON(OperationMessage{Type: "connection_error", Payload: payload})
+		return
+	}
+	if err := s.connectionInit(op.Payload); err != nil {
+		payload, _ := json.Marshal(graphql.NewResponse().AddError(err))
+		s.writeJSON(OperationMessage{Type: "connection_error", Payload: payload})
+		s.closeWithCode(websocket.ClosePolicyViolation, err.Error())
 		return
 	}
 
-	writeJSON(OperationMessage{Type: "connection_ack"})
+	s.connectionAck("ka")
 	for {
 
 		msg := OperationMessage{}
-		err := conn.ReadJSON(&msg)
+		err := s.conn.ReadJSON(&msg)
 		if err != nil {
 			return
 		}
+		s.extendReadDeadline()
 
 		switch msg.Type {
 		case "start":
-
 			var request graphql.Request
 			err := json.Unmarshal(msg.Payload, &request)
 			if err != nil {
 				return
 			}
-
-			ctx := r.Context()
-			ctx = context.WithValue(ctx, "net/http.ResponseWriter", w)
-			ctx = context.WithValue(ctx, "*net/http.Request", r)
-
-			stream := wsStream{}
-			request.Context, stream.cancel = context.WithCancel(ctx)
-			stream.responses = streamingHandlerFunc(&request)
-
-			// save it.. so that client can later cancel it...
-			mu.Lock()
-			streams[msg.Id] = stream
-			mu.Unlock()
-
-			// Start a goroutine ot handle the events....
-			go func() {
-				for {
-					r := <-stream.responses
-					if r != nil {
-						payload, err := json.Marshal(r)
-						if err != nil {
-							panic(fmt.Sprintf("could not marshal payload: %v\n", err))
-						}
-						writeJSON(OperationMessage{Type: "data", Id: msg.Id, Payload: payload})
-					} else {
-
-						mu.Lock()
-						delete(streams, msg.Id)
-						mu.Unlock()
-
-						writeJSON(OperationMessage{Type: "complete", Id: msg.Id})
-						stream.cancel()
-						return
-					}
-				}
-			}()
+			s.start(msg.Id, &request, "data", "")
 
 		case "stop":
-			mu.Lock()
-			stream, ok := streams[msg.Id]
-			mu.Unlock()
+			s.mu.Lock()
+			stream, ok := s.streams[msg.Id]
+			s.mu.Unlock()
+			if ok {
+				stream.cancel()
+			}
+
+		case "connection_terminate":
+			return
+		}
+	}
+}
+
+// serveGraphQLTransportWS implements the graphql-transport-ws protocol, see:
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+func (s *wsSession) serveGraphQLTransportWS(initWaitTimeout time.Duration) {
+	s.conn.SetReadDeadline(time.Now().Add(initWaitTimeout))
+	msg, err := s.readTransportWSMessage()
+	if err != nil {
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			s.closeWithCode(4408, "Connection initialisation timeout")
+		}
+		return
+	}
+	switch msg.Type {
+	case "connection_init":
+	case "subscribe":
+		s.closeWithCode(4401, "Unauthorized")
+		return
+	default:
+		s.closeWithCode(4400, fmt.Sprintf("Unexpected message type: %s", msg.Type))
+		return
+	}
+	s.conn.SetReadDeadline(time.Time{})
+	s.extendReadDeadline()
+	if err := s.connectionInit(msg.Payload); err != nil {
+		s.closeWithCode(4403, "Forbidden: "+err.Error())
+		return
+	}
+
+	s.connectionAck("ping")
+	for {
+		msg, err := s.readTransportWSMessage()
+		if err != nil {
+			return
+		}
+		s.extendReadDeadline()
+
+		switch msg.Type {
+		case "connection_init":
+			s.closeWithCode(4429, "Too many initialisation requests")
+			return
+
+		case "ping":
+			s.writeJSON(OperationMessage{Type: "pong"})
+
+		case "pong":
+
+		case "subscribe":
+			id, ok := msg.Id.(string)
+			if !ok || id == "" {
+				s.closeWithCode(4400, "Invalid subscribe message id")
+				return
+			}
+			s.mu.Lock()
+			_, exists := s.streams[id]
+			s.mu.Unlock()
+			if exists {
+				s.closeWithCode(4409, fmt.Sprintf("Subscriber for %s already exists", id))
+				return
+			}
+			var request graphql.Request
+			if err := json.Unmarshal(msg.Payload, &request); err != nil {
+				s.closeWithCode(4400, err.Error())
+				return
+			}
+			s.start(id, &request, "next", "error")
+
+		case "complete":
+			s.mu.Lock()
+			stream, ok := s.streams[msg.Id]
+			delete(s.streams, msg.Id)
+			s.resetIdleTimer()
+			s.mu.Unlock()
 			if ok {
 				stream.cancel()
 			}
+
+		default:
+			s.closeWithCode(4400, fmt.Sprintf("Unexpected message type: %s", msg.Type))
+			return
 		}
 	}
 }
+
+// readTransportWSMessage reads the next message, invalid messages close the socket with a 4400 code.
+func (s *wsSession) readTransportWSMessage() (OperationMessage, error) {
+	msg := OperationMessage{}
+	_, data, err := s.conn.ReadMessage()
+	if err != nil {
+		return msg, err
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		s.closeWithCode(4400, "Invalid message received")
+		return msg, err
+	}
+	return msg, nil
+}
@@ -1,13 +1,23 @@
 package httpgql_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/chirino/graphql"
 	"github.com/chirino/graphql/httpgql"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type testStream struct {
@@ -46,3 +56,313 @@ func TestClientServeGraphQLStream(t *testing.T) {
 	response = <-rs
 	assert.Nil(t, response)
 }
+
+func dialWebsocket(t *testing.T, url string, subprotocol string) *websocket.Conn {
+	wsUrl, err := httpgql.ToWsURL(url)
+	require.NoError(t, err)
+	dialer := websocket.Dialer{Subprotocols: []string{subprotocol}}
+	conn, _, err := dialer.Dial(wsUrl, nil)
+	require.NoError(t, err)
+	require.Equal(t, subprotocol, conn.Subprotocol())
+	return conn
+}
+
+func helloWorldStream(request *graphql.Request) graphql.ResponseStream {
+	result := make(chan *graphql.Response, 1)
+	result <- &graphql.Response{
+		Data: json.RawMessage(`{"hello":"world"}`),
+	}
+	close(result)
+	return result
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) string {
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	return strings.TrimSpace(string(data))
+}
+
+func TestGraphQLWSProtocol(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{ServeGraphQLStream: helloWorldStream})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLWS)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: 1, Type: "start", Payload: json.RawMessage(`{"query":"{hello}"}`)}))
+	assert.Equal(t, `{"id":1,"type":"data","payload":{"data":{"hello":"world"}}}`, readMessage(t, conn))
+	assert.Equal(t, `{"id":1,"type":"complete"}`, readMessage(t, conn))
+}
+
+func TestGraphQLTransportWSProtocol(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream:        helloWorldStream,
+		ConnectionInitWaitTimeout: 100 * time.Millisecond,
+	})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLTransportWS)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "ping"}))
+	assert.Equal(t, `{"type":"pong"}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: "a", Type: "subscribe", Payload: json.RawMessage(`{"query":"{hello}"}`)}))
+	assert.Equal(t, `{"id":"a","type":"next","payload":{"data":{"hello":"world"}}}`, readMessage(t, conn))
+	assert.Equal(t, `{"id":"a","type":"complete"}`, readMessage(t, conn))
+
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, 4429), "unexpected error: %v", err)
+
+	// the server closes the socket when the client does not initialize the connection in time.
+	conn = dialWebsocket(t, s.URL, httpgql.GraphQLTransportWS)
+	defer conn.Close()
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, 4408), "unexpected error: %v", err)
+}
+
+type userKey struct{}
+
+func TestOnConnectionInit(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{
+		OnConnectionInit: func(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+			init := struct{ Token string }{}
+			if err := json.Unmarshal(payload, &init); err != nil || init.Token != "secret" {
+				return nil, errors.New("invalid token")
+			}
+			return context.WithValue(ctx, userKey{}, "hiram"), nil
+		},
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			result := make(chan *graphql.Response, 1)
+			result <- &graphql.Response{
+				Data: json.RawMessage(fmt.Sprintf(`{"user":%q}`, request.Context.Value(userKey{}))),
+			}
+			close(result)
+			return result
+		},
+	})
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.ConnectionInitPayload = json.RawMessage(`{"token":"secret"}`)
+	response := <-client.ServeGraphQLStream(&graphql.Request{Query: "{user}"})
+	require.NotNil(t, response)
+	assert.Equal(t, `{"user":"hiram"}`, string(response.Data))
+
+	client = httpgql.NewClient(s.URL)
+	client.ConnectionInitPayload = json.RawMessage(`{"token":"wrong"}`)
+	response = <-client.ServeGraphQLStream(&graphql.Request{Query: "{user}"})
+	require.NotNil(t, response)
+	assert.Contains(t, response.Error().Error(), "4403")
+
+	// legacy clients get a connection_error message.
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLWS)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init", Payload: json.RawMessage(`{"token":"wrong"}`)}))
+	assert.Equal(t, `{"type":"connection_error","payload":{"errors":[{"message":"invalid token"}]}}`, readMessage(t, conn))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), "unexpected error: %v", err)
+}
+
+func TestWebsocketKeepAlive(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: helloWorldStream,
+		KeepAliveInterval:  10 * time.Millisecond,
+	})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLWS)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+	assert.Equal(t, `{"type":"ka"}`, readMessage(t, conn))
+	assert.Equal(t, `{"type":"ka"}`, readMessage(t, conn))
+}
+
+func TestWebsocketReadTimeout(t *testing.T) {
+	canceled := make(chan struct{})
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			result := make(chan *graphql.Response)
+			go func() {
+				<-request.Context.Done()
+				close(canceled)
+				close(result)
+			}()
+			return result
+		},
+		ReadTimeout: 50 * time.Millisecond,
+	})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLWS)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: 1, Type: "start", Payload: json.RawMessage(`{"query":"subscription {hello}"}`)}))
+
+	// the client goes quiet, so the server should cancel the stream.
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream was not canceled")
+	}
+}
+
+func TestWebsocketMaxIdleTime(t *testing.T) {
+	s := httptest.NewServer(&httpgql.Handler{
+		ServeGraphQLStream: helloWorldStream,
+		MaxIdleTime:        50 * time.Millisecond,
+	})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLTransportWS)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: "1", Type: "subscribe", Payload: json.RawMessage(`{"query":"{hello}"}`)}))
+	assert.Equal(t, `{"id":"1","type":"next","payload":{"data":{"hello":"world"}}}`, readMessage(t, conn))
+	assert.Equal(t, `{"id":"1","type":"complete"}`, readMessage(t, conn))
+
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error: %v", err)
+}
+
+// trackingListener remembers the accepted connections so that tests can break them.
+type trackingListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *trackingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, conn)
+		l.mu.Unlock()
+	}
+	return conn, err
+}
+
+func (l *trackingListener) closeConnections() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, conn := range l.conns {
+		conn.Close()
+	}
+	l.conns = nil
+}
+
+func TestClientReconnect(t *testing.T) {
+	subscriptions := int32(0)
+	s := httptest.NewUnstartedServer(&httpgql.Handler{
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			n := atomic.AddInt32(&subscriptions, 1)
+			result := make(chan *graphql.Response, 1)
+			result <- &graphql.Response{
+				Data: json.RawMessage(fmt.Sprintf(`{"subscription":%d}`, n)),
+			}
+			go func() {
+				<-request.Context.Done()
+				close(result)
+			}()
+			return result
+		},
+	})
+	listener := &trackingListener{Listener: s.Listener}
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.Reconnect = &httpgql.ReconnectPolicy{InitialBackoff: 10 * time.Millisecond}
+
+	rs := client.ServeGraphQLStream(&graphql.Request{Query: "subscription {hello}"})
+	response := <-rs
+	require.NotNil(t, response)
+	assert.Equal(t, `{"subscription":1}`, string(response.Data))
+
+	// break the connection, the client should reconnect and restart the subscription.
+	listener.closeConnections()
+
+	response = <-rs
+	require.NotNil(t, response)
+	assert.NoError(t, response.Error())
+	assert.Equal(t, `{"subscription":2}`, string(response.Data))
+}
+
+func TestClientReconnectDoesNotRestartMutations(t *testing.T) {
+	executions := int32(0)
+	started := make(chan struct{}, 10)
+	s := httptest.NewUnstartedServer(&httpgql.Handler{
+		ServeGraphQLStream: func(request *graphql.Request) graphql.ResponseStream {
+			atomic.AddInt32(&executions, 1)
+			started <- struct{}{}
+			// the mutation is still running when the connection breaks.
+			result := make(chan *graphql.Response)
+			go func() {
+				<-request.Context.Done()
+				close(result)
+			}()
+			return result
+		},
+	})
+	listener := &trackingListener{Listener: s.Listener}
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	client := httpgql.NewClient(s.URL)
+	client.Reconnect = &httpgql.ReconnectPolicy{InitialBackoff: 10 * time.Millisecond}
+
+	rs := client.ServeGraphQLStream(&graphql.Request{Query: "mutation {hello}"})
+	<-started
+	listener.closeConnections()
+
+	response := <-rs
+	require.NotNil(t, response)
+	assert.Error(t, response.Error())
+	_, open := <-rs
+	assert.False(t, open)
+
+	// give a replay the chance to reach the server.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&executions))
+}
+
+func TestGraphQLTransportWSProtocolErrors(t *testing.T) {
+	engine := graphql.New()
+	require.NoError(t, engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		type Query {
+			hello: String
+		}
+	`))
+	engine.Root = map[string]interface{}{"hello": "world"}
+	s := httptest.NewServer(&httpgql.Handler{ServeGraphQLStream: engine.ServeGraphQLStream})
+	defer s.Close()
+
+	conn := dialWebsocket(t, s.URL, httpgql.GraphQLTransportWS)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "connection_init"}))
+	assert.Equal(t, `{"type":"connection_ack"}`, readMessage(t, conn))
+
+	// requests that don't validate get an error message and no complete message.
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: "a", Type: "subscribe", Payload: json.RawMessage(`{"query":"{missing}"}`)}))
+	assert.Equal(t, `{"id":"a","type":"error","payload":[{"message":"Cannot query field \"missing\" on type \"Query\".","locations":[{"line":1,"column":2}]}]}`, readMessage(t, conn))
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Type: "ping"}))
+	assert.Equal(t, `{"type":"pong"}`, readMessage(t, conn))
+
+	// the id can be reused since the stream was dropped.
+	require.NoError(t, conn.WriteJSON(httpgql.OperationMessage{Id: "a", Type: "subscribe", Payload: json.RawMessage(`{"query":"{hello}"}`)}))
+	assert.Equal(t, `{"id":"a","type":"next","payload":{"data":{"hello":"world"}}}`, readMessage(t, conn))
+	assert.Equal(t, `{"id":"a","type":"complete"}`, readMessage(t, conn))
+}
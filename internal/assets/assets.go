@@ -24,9 +24,9 @@ var FileSystem = func() http.FileSystem {
 		"/meta.graphql": &vfsgen۰CompressedFileInfo{
 			name:             "meta.graphql",
 			modTime:          time.Time{},
-			uncompressedSize: 6923,
+			uncompressedSize: 8744,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x5b\x6f\x1b\xb9\xf5\x7f\x9f\x4f\x71\xac\x97\x7f\x0c\xc8\x63\xec\xbf\x29\x5a\xb8\x58\xa0\x4a\x2c\x67\x67\xeb\xc8\x59\x5b\x4a\x51\x04\xa9\x86\x9a\x39\xa3\xe1\x9a\x22\x27\x24\xc7\x8a\xb0\xbb\xdf\xbd\x38\x24\xe7\x26\x59\x72\x82\xa2\x2f\x89\x35\xe4\xf9\x9d\xfb\x8d\xa3\xd1\x28\x9a\x97\x08\x69\x22\x6d\x0a\x26\x63\x82\x69\xb0\xbb\x0a\x41\x63\xa5\xd1\xa0\xb4\x06\xa4\x92\x17\x85\x66\x99\xe5\x4a\x32\x01\x86\xaf\x25\xe6\xb0\x2d\x95\x40\x90\xf5\x06\x35\xcf\xe0\x89\x89\x1a\x4d\x0c\x89\xb4\x90\x31\xd9\x91\x47\xfe\x04\x56\x68\xb7\x88\x12\x2e\x5e\xfd\xff\xbf\xff\xf4\xc3\x39\x30\x99\x03\xfd\x05\x17\xf0\x43\x1c\x91\x1c\x81\x7b\x22\x6d\x14\xb5\x72\xdd\x08\xc5\x8e\x4b\x16\x44\xc9\x55\xbd\x12\x78\x51\x69\xcc\xb8\xe1\x4a\x42\x4f\xda\xc0\x9e\x19\x30\x15\x66\xbc\xe0\x98\xc3\x6a\x17\x7d\x4a\xa6\xd3\x29\xfc\xe5\xcf\xaf\x3f\xbf\x2a\xad\xad\xae\x2e\x2f\x51\xc6\x5b\xfe\xc8\x2b\xcc\x39\x8b\x95\x5e\x5f\xd2\xaf\x4b\xba\xb6\x2c\x48\x08\x2e\xd7\xcb\x4a\x71\x69\xcf\x07\xe2\x3a\x01\x7b\x02\x3f\x58\xcd\xe5\xfa\xa8\xc4\x16\xbf\xda\x9a\x09\xc8\x99\x65\xe3\xee\x00\x73\x92\x70\x31\xbf\xb9\xf8\x2b\x64\x25\x23\xf1\x51\x83\xc1\x2f\x35\xca\x0c\x4d\xec\xb0\x3d\xb4\x87\xe4\x06\x36\xca\x58\x50\x85\x45\x09\xb5\x71\x5a\xc1\x3b\xcd\xaa\xf2\x97\x5b\xb0\xaa\x83\x86\x42\x23\x5e\x14\x4a\x6f\xa0\xac\x37\x4c\x5e\x68\x64\x39\x5b\x09\x74\xb2\x0c\x74\xf1\x0c\x7a\xca\xbc\x51\x4a\x20\x93\x47\xb5\x49\xad\xae\x31\x05\xa5\x21\x2d\x98\x30\x98\x0e\xe0\x02\x75\x0f\x2f\xb9\x3e\x0a\xc5\xa0\x96\xfc\x4b\x8d\xc0\x73\x94\x96\xfc\xa4\xc7\x7d\xed\x9c\x4a\x05\xda\xac\x04\x26\x41\xad\x7e\xc5\xcc\x12\x63\x66\xe0\x11\x77\x50\xd0\x9f\x90\xb1\xac\x44\x6f\xac\xe4\xda\xb3\x60\x55\x85\x4c\x1b\xe0\x12\x18\xfc\xfc\x70\x37\x03\x8d\xa6\x52\xd2\x20\x91\xb2\xa0\xf3\xdf\xa0\x54\x5b\x7c\x22\x9e\xdc\x92\x71\xa5\xb2\xc0\xa5\x45\x99\x7b\xde\x2b\xdc\xb3\x5e\x1c\xfd\xb3\x44\x09\xf8\xb5\xc2\x2c\xb8\x8f\x49\xe0\xb2\xaa\xad\x63\x3c\x06\x26\x77\x60\xbc\xcb\x5e\x99\x9a\xe4\x36\x90\x8e\x5e\x8f\xd2\x73\x92\x9b\xc0\xd7\xa8\x7b\x47\xaf\xd3\xf3\x40\xef\x62\x16\xb6\x5c\x08\xe2\xcb\xb2\x0c\x2b\x8b\x79\xe4\x59\x24\xd7\xc3\x74\xb9\xf6\xf6\xbd\xe6\x1a\x33\x0a\xb0\x12\x01\xbf\x62\x56\x5b\xa5\x49\x70\x2e\x33\x51\xe7\x08\xb6\xe4\x06\x0a\x8e\x22\x27\xee\x85\x66\xeb\x0d\x05\x87\x92\x62\x07\x5b\xd2\x84\x08\x53\x5e\xa4\xc0\xf4\xba\x76\x67\xdc\x00\xf9\xd7\xb3\xcb\x1d\x3e\x7f\x42\xf8\x7b\x80\x7c\x15\x01\x00\x8c\x12\xff\x2b\x0f\x28\x44\x30\x72\x27\xbc\xb8\x6a\x42\xe0\x2c\x3a\x07\x25\xe1\x26\x99\xde\x5e\xc3\xef\x70\x73\x3f\x79\xf7\x7e\x3a\x9b\x2f\x1f\x3e\xdc\x4f\x27\xf4\x25\x99\xdd\x26\xb3\xe9\xb2\x39\x38\xad\x92\x79\xe4\xd5\x31\x7d\xbe\x53\x15\x82\x0a\x7a\x3c\x3c\xf2\xaa\xfa\x5f\xa8\xf1\x9e\xe9\x47\xe7\x39\x14\xe8\x6d\x5e\x00\x6b\x53\xd5\x64\x25\x6e\x18\x05\x80\x54\x20\x94\xa4\x98\x30\x75\x55\x29\x6d\x31\x3f\x90\x37\xa7\x84\xc9\x98\xc5\x3c\x48\x3d\xf2\x42\x4e\xbf\x56\x82\x71\x69\x60\x5b\xee\xbc\x69\x1a\x66\x5b\x66\xa0\xa3\x1a\x43\x6d\x6a\x26\xc4\x0e\x98\x30\x4d\x70\x50\x84\x32\x30\xf5\x7a\x8d\x86\xaa\xa6\x43\xa4\x84\x2a\xd5\x96\xec\x4d\x01\x68\x4c\x27\x15\x18\xbe\xe1\x14\x7b\x54\xc4\x62\xb8\x51\x7a\xc3\x2c\x7d\xe7\x9e\xf4\x13\x69\x9c\xab\xad\xf4\xb5\xd5\x5c\x5d\x5e\xe6\x8c\xf2\xa0\xe0\x1a\x57\x4c\x88\x58\xa2\xbd\xac\xb4\xa2\x1c\x36\x97\x9b\x70\xfb\xf2\x3c\x1e\xe8\xa4\x91\x19\x25\xaf\x9a\xb2\xf7\x23\x8c\x66\x87\x16\x1a\xf5\x5c\xb2\xbc\x9e\xde\x24\xb3\x64\x9e\xdc\xcd\xe0\x77\x98\xce\x16\xef\x97\x1f\x27\xb7\x8b\xa9\x77\xc3\x04\xae\x5b\x3b\x56\x5a\x3d\xf1\x9c\xda\x02\x6c\xd9\x8e\x74\xcc\xd1\x64\x9a\x53\xb6\x09\x8b\x5a\x32\x8b\xa0\x6b\x69\xf9\xa6\x09\x3d\x6a\x2b\xd4\xb3\x5c\x51\x79\x62\x82\xe7\xcc\x7d\x5b\x61\xc9\x9e\xb8\x4b\xe8\x9e\x57\x73\x95\xb9\xc0\x8b\xa3\x28\x91\x60\xd4\x06\x21\x63\x06\xcd\x18\x76\xaa\x06\x89\xbe\xa8\x04\x31\x40\x55\x04\x65\x9c\xad\x89\x7d\x03\xf3\x7f\xa6\xc7\xbc\x61\x14\x71\x49\x42\x37\xc1\xdf\x84\xb8\xf1\xf5\x82\xea\x96\xa9\x8b\x82\x67\x38\x86\xa6\xb4\x64\x4a\xe6\xdc\xf7\x43\xb1\xeb\x39\x5d\xe9\x88\x12\xa0\xf2\x01\xe0\xf0\xe2\xce\x4a\xa6\x95\xcf\x05\xd4\x6a\xd7\x18\xc9\x5d\xcf\x1b\x44\xe0\xb2\x70\x11\x40\x91\x63\xd5\x20\x5b\x7d\xfc\x3a\x93\x2d\x97\x9d\xf9\x7f\x73\xfe\x95\x6c\x83\x8d\x77\xcf\xdc\x17\x8f\xef\x8c\xd1\x1c\xb8\xef\x42\x65\x0e\xde\x5c\xc1\xa7\x1e\xce\x6d\xf8\x7c\xf6\xd9\x93\x33\xbd\xf6\x37\x12\xaa\xa2\x1f\xa9\x88\xd2\xd1\x1f\x87\xfe\xa7\x09\x85\x7c\x9d\xff\xca\x32\x4a\x12\xab\x60\x43\xe5\xba\x62\xda\x1a\xca\x4f\x52\xa2\xf1\xa5\x60\x72\x5d\xb3\x35\x55\xf4\xe8\x19\xee\x6d\xe8\x18\x50\x12\xbd\xcd\x2b\x65\x0c\xa7\x16\x1b\x38\x64\x9c\x7a\x38\x49\x81\xb2\xde\xc0\x73\x28\xde\x26\xa3\xf6\x77\x5f\x36\x06\x5f\x6a\xd4\x3b\x50\x15\x6a\x77\x1a\x4a\xd3\x2f\x8b\xe9\xfd\xbf\x4e\xd2\x6d\x6a\xeb\x3f\xef\x93\xbe\x5f\xcc\x27\x94\x28\x27\xa9\x4d\xbd\x6a\x1d\x72\x80\xf0\xb0\x78\xf3\xf0\xf6\x3e\xf9\xf0\x22\x8a\x8f\x2b\x4f\xe4\xf2\xf4\xf4\xed\xa6\x92\xe7\x58\x70\xc9\x7b\x0c\xdb\x72\xdb\xa5\xf9\xb7\x21\x99\x8a\x9a\xf6\x3e\x8a\x2f\xda\xa7\x10\xa8\x9d\x0b\x2e\xb1\x45\x0a\x10\xfb\x55\xfe\xa4\x09\x7d\x79\x3f\x50\xe6\xe1\xed\x4f\xd3\xf7\x93\x17\x48\x5d\x83\x7f\x86\x74\x72\x3b\xb9\x3f\x2d\x78\x98\x8f\x5c\xe6\x1d\x00\xdc\xbd\xf9\x79\xfa\xf6\xb4\xd8\xbe\xb6\x1c\xba\x60\xaf\xcc\x9e\x16\xa2\xed\xbe\x07\x38\x93\xfb\x77\x8b\xef\x70\xa5\x74\xd3\x92\x2e\x58\xf6\x8c\x36\xc9\x6c\x3e\xbd\xbf\x99\xbc\x9d\x9e\x54\xa8\x96\x3e\x55\xf7\x88\x17\xb3\x17\x59\xbb\x8c\x3d\x20\xa4\xee\xf2\x0d\x74\x7e\x92\x7b\x96\x3a\xf4\xa6\x17\xd4\xa6\x71\xf0\xa4\x33\x93\xd9\x87\xc5\x7c\xf9\xb2\x4b\xf7\xc0\x8e\x38\xd8\xa3\x1d\xb8\x39\x94\xd0\x3b\x89\x5d\x69\xf3\xaa\xf9\xb9\x7b\xcd\x9f\x50\xc2\x54\xd6\x9b\xd8\xfd\xdb\x6e\x5d\x1a\x9b\xb1\xde\x7f\x19\xbb\x06\xc5\xa2\x4a\xb0\x0c\x4b\x25\x72\xd4\x01\x22\x4c\xca\x4a\x0f\x57\xca\x18\x7e\xf2\x83\x39\x69\xd0\x41\xd3\x40\xa7\xd1\xd6\x5a\xfa\xb1\xe3\xd9\xd1\xde\x43\x0e\x7a\x10\x21\xb8\xc6\xf0\xdd\x3d\x88\x9b\xeb\x76\x8a\xea\x0d\x84\x9e\xc4\x1f\x70\x25\xef\x07\x33\x4b\x6b\x37\x6f\x73\x1a\x1f\x92\x36\x90\x49\x22\x6f\xa1\xa6\x81\xb8\x15\x8e\x81\xe0\x6e\xaf\x83\x1b\x72\x91\x19\x03\xb2\xac\xa4\xdf\xdb\x92\x67\x25\x94\xcc\x44\xcc\xc9\x3d\x86\x4a\x59\xda\x95\xfc\x3c\xd7\xd2\xb5\x13\xc1\xd8\x71\x64\xc1\x50\x8e\xe1\xc0\x18\x8e\xc1\x77\x1b\xe2\x48\x97\xa5\x23\xc2\xbd\x82\xe5\x72\xbe\xab\xf0\xec\xbf\xb6\xda\xa4\x9d\x6c\xc2\x1c\xe2\x66\x26\x6f\x15\x8a\x93\xde\xa0\xe2\x06\xb3\x12\x43\x88\x17\xe1\x4a\x01\x4c\x46\x4e\xca\xc6\x01\x1a\xf7\x57\x6e\x77\x0c\x1f\x7d\xb8\x7a\x0b\xb7\xa3\xa0\x2d\x91\x87\x45\x95\xc9\x3c\xf2\x53\x5a\x30\x76\x8e\x05\xab\x45\x58\xd5\x06\x56\xed\xcc\xf2\xdd\xa6\x3d\xb4\xdf\x68\xd2\x8c\x21\x17\x45\x3b\x66\x87\x54\x69\x35\x71\x8f\x02\x25\x0e\x65\x72\x69\xe5\x06\xb7\xde\x4e\x19\xb2\x3c\x5c\x74\x42\x1e\x58\xbd\x9d\x7b\x1e\x7a\xad\x0b\xfd\x16\x96\xb1\x8a\xad\xb8\xe0\x96\xa3\xd9\x5b\x63\x50\x3f\xa1\x8e\x21\xb1\xb4\x12\x2b\x43\x4e\x11\x22\x62\x4f\x8c\x0b\xff\xd8\xe0\xc3\x5d\xe6\x90\x77\x7e\x53\x7e\x57\xf3\xc4\x63\xf2\xc7\x16\x85\xa0\xff\xdd\x14\x29\xad\xde\x81\x7b\x73\x31\xa4\x4e\xe4\xa6\xa0\x71\x3b\xd4\xf8\xf8\x7e\x7e\x48\x31\x03\x9f\x04\x55\x7e\x6b\x6c\xda\xa6\x8a\x10\x41\xb0\x6e\xb9\x59\x85\x05\x2a\x68\x34\x6a\x3d\xe3\xa3\xde\xf9\x26\xc4\xfb\x68\x5e\x7a\xc5\xc0\x96\xcc\xee\x0f\x69\xa6\xdd\xe0\xb5\x52\x2e\xdc\x9a\x01\xc2\x5d\x9c\x1f\x3a\x3b\x29\xfa\xac\x1b\x99\x4c\x4f\x61\x3b\x60\x78\x38\xdd\x1d\xe7\xd9\xdc\xed\xb3\x3d\xc5\x75\x60\xd7\x7d\xc6\x47\x6c\x7e\x94\x79\xff\xfe\xa1\x00\x43\x7f\xf4\xc2\xe3\x05\xa7\x74\x37\x87\x7b\x41\x6f\xe8\x27\x07\x15\xb5\xcc\x19\x15\x12\x26\xa8\x19\x79\x46\x72\xb7\x1f\xe7\xdc\xb4\x4a\xc6\x30\x2f\x51\xa3\xab\x17\x6e\x2f\x78\xe4\xd2\x15\x94\xc8\x07\x0b\x97\x2d\x31\x33\x83\x8a\xe2\xe4\x44\x48\xbd\x7a\xff\xe0\x32\x4f\xdd\x20\x10\x47\xd1\x35\x56\x28\xfd\xda\xe5\xa3\x9e\x40\x7d\x12\xf9\xf7\xa2\x0c\xb5\x65\x5c\x36\xe5\xab\x2d\x43\xbd\xfd\x0a\xd8\x4a\xd5\xd6\xf9\x20\xf2\x82\x3e\x74\xaf\x69\xdd\xbe\x26\xd5\x80\x68\x85\x3b\xe5\x1a\x01\x55\x22\x9f\x82\xd8\x73\xed\xb6\xe4\x02\x23\xd7\x5b\x87\x30\xbe\xfe\x35\x2f\xbb\x27\x9a\x58\x8f\x20\x48\x1f\xd9\x12\xdb\x7d\x11\x63\x98\xac\x8c\xd5\x2c\x0c\x31\x66\x0c\x0b\xd9\xec\xd3\x2d\xd6\x78\x00\x73\xd7\xcd\x3c\x26\xea\xd6\x29\xdb\x6c\xe5\x31\xdc\x52\xcc\x10\xc2\x4c\xc9\x59\xdd\x26\x72\xa6\x36\x54\x7e\x40\xd9\x12\x83\x5d\x06\xa5\x80\xfc\x12\x0a\x01\x39\xa0\x89\x44\x72\xd5\xd9\x41\xb9\x3e\x59\xad\xbd\xa6\xaf\xc2\x6b\xd8\x33\x7d\x0e\x7e\x04\xf7\x2a\x7a\xee\xc2\xd3\x35\xae\xb3\xcf\xbe\x2d\x36\x4a\xf7\x6b\x8a\x3b\x69\x54\x9d\xef\x15\x1c\x77\x88\xcd\xf4\xf2\xed\x5c\xdb\x81\xa7\xe5\x5c\xd5\xd6\xf7\xd0\x83\x26\xee\x2e\xa8\x62\x90\xa0\x4d\x57\x68\xe7\xe0\xf6\x05\x60\x4b\x85\xa0\x89\x61\xdf\x24\xc3\x1c\x18\xa2\x3f\x05\x3e\x5c\x7a\x1b\x3b\x37\x75\x38\x91\x39\x27\xd9\x8d\xcf\xed\xe6\x5d\xbb\xd9\x7c\x9e\x59\x77\x8e\x51\x34\x0b\x4f\x0c\xa9\xf7\x4a\xea\x22\x23\xed\xec\x9c\xba\x64\x76\xef\x36\xc1\x71\xcf\x2c\x43\xc7\xe1\x5b\xa0\x7d\x0e\x03\x7f\x1d\x65\xb2\xb7\xa3\x1c\x55\xdc\x6d\x29\x31\xec\x81\xba\xa3\x1e\xea\xe1\xee\x72\x5c\x70\x57\x7c\x20\xed\x22\xe7\x28\x5c\xb7\xd1\x9c\x32\x43\xb7\x42\xc4\x64\xde\x36\x98\x8e\xc2\x1e\xee\x27\x47\xb5\xa7\x2e\x10\x43\xea\x23\xf0\x28\xe0\x6d\xf2\xf0\x12\x90\x54\xf2\x42\xd6\x42\xbc\x0c\x36\xbb\x9b\x2d\x67\x8b\xdb\xdb\xe8\x8f\xe8\x3f\x01\x00\x00\xff\xff\x26\x38\xae\xc2\x0b\x1b\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x5a\x5b\x6f\xe3\xc6\x15\x7e\xe7\xaf\x38\xab\x97\xae\x01\x59\x6e\xda\x14\x2d\x5c\x04\xa8\xd6\x96\x13\xa5\x5a\x39\xb1\xa5\x14\x45\xb0\xb5\x46\xe4\xa1\x38\xf1\x70\x86\x99\x19\x5a\x16\x9a\xfc\xf7\xe2\xcc\x85\x17\xeb\xe2\x35\x92\xbe\x24\x16\x39\xf3\x9d\xfb\x95\x3b\x18\x0c\x92\x45\x81\xb0\x9a\x4a\xbb\x02\x93\x32\xc1\x34\xd8\x5d\x85\xa0\xb1\xd2\x68\x50\x5a\x03\x52\xc9\xf3\x5c\xb3\xd4\x72\x25\x99\x00\xc3\x37\x12\x33\xd8\x16\x4a\x20\xc8\xba\x44\xcd\x53\x78\x62\xa2\x46\x33\x82\xa9\xb4\x90\x32\xd9\x5e\x4f\xfc\x1b\x58\xa3\xdd\x22\x4a\x38\x7f\xff\xa7\xff\xfc\xf9\x8b\x33\x60\x32\x03\xfa\x0b\xce\xe1\x8b\x51\x42\x7c\x04\xea\x53\x69\x93\xa4\xe1\xeb\x46\x28\x76\x9c\xb3\xc0\x4a\xa6\xea\xb5\xc0\xf3\x4a\x63\xca\x0d\x57\x12\x3a\xdc\x06\xf2\xcc\x80\xa9\x30\xe5\x39\xc7\x0c\xd6\xbb\xe4\xc7\xe9\x64\x32\x81\xbf\xfe\xe5\xcb\x4f\xef\x0b\x6b\xab\xcb\x8b\x0b\x94\xa3\x2d\x7f\xe4\x15\x66\x9c\x8d\x94\xde\x5c\xd0\xaf\x0b\x3a\xf6\x90\x13\x13\x5c\x6e\x1e\x2a\xc5\xa5\x3d\xeb\xb1\xeb\x18\xec\x30\x7c\x6f\x35\x97\x9b\xa3\x1c\x5b\x7c\xb6\x35\x13\x90\x31\xcb\x86\xed\x0b\xcc\x80\x19\x58\x2e\x6e\xce\xff\x06\x69\xc1\x88\x7d\xd4\x60\xf0\xe7\x1a\x65\x8a\x66\xe4\x94\xe1\xa1\x3d\x24\x37\x50\x2a\x63\x41\xe5\x16\x25\xd4\xc6\x49\x05\x5f\x6b\x56\x15\xdf\xcf\xc0\xaa\x16\x1a\x72\x8d\x78\x9e\x2b\x5d\x42\x51\x97\x4c\x9e\x6b\x64\x19\x5b\x0b\x74\xbc\xf4\x64\xf1\x04\x3a\xc2\x7c\x50\x4a\x20\x93\x47\xa5\x59\x59\x5d\xe3\x0a\x94\x86\x55\xce\x84\xc1\x55\x0f\x2e\xdc\xee\xe0\x4d\xaf\x8f\x42\x31\xa8\x25\xff\xb9\x46\xe0\x19\x4a\x4b\x76\xd2\xc3\xae\x74\x4e\xa4\x1c\x6d\x5a\x00\x93\xa0\xd6\x3f\x61\x6a\x89\x30\x33\xf0\x88\x3b\xc8\xe9\x4f\x48\x59\x5a\xa0\x57\xd6\xf4\xda\x93\x60\x55\x85\x4c\x1b\xe0\x12\x18\x7c\x7b\x7f\x3b\x07\x8d\xa6\x52\xd2\x20\x69\x9c\x05\x99\xff\x0e\x85\xda\xe2\x13\xea\x21\x70\x0b\x9c\x7c\xde\x02\x97\x16\x65\xe6\x69\xaf\xf1\x85\xf6\x46\xc9\xbf\x0a\x94\x80\xcf\x15\xa6\xc1\x7c\x4c\x02\x97\x55\x6d\x1d\xe1\x21\x30\xb9\x03\xe3\xd0\xe1\xbd\xa9\x89\x6f\x03\xab\xc1\x97\x83\xd5\x19\xf1\x4d\xe0\x1b\xd4\x9d\x57\x5f\xae\xce\xc2\x7d\xe7\xb3\xb0\xe5\x42\xc0\x1a\x81\xa5\x29\x56\x16\xb3\xc4\x93\x98\x5e\xf7\x94\x3c\xbd\xf6\xfa\xbd\xe6\x1a\x53\x72\xb0\x02\x01\x9f\x31\xad\xad\xd2\xc4\x38\x97\xa9\xa8\x33\x04\x5b\x70\x03\x39\x47\x91\x11\xf5\x5c\xb3\x4d\x89\xd2\x82\x92\x62\x07\x5b\x92\xc4\x52\xb8\xf1\x7c\x05\x4c\x6f\x6a\xf7\x8e\x1b\x20\xfb\x7a\x72\x99\xc3\xe7\x4f\x08\xff\x08\x90\xef\x13\x00\x80\xc1\xd4\xff\xca\x02\x0a\x5d\x18\xb8\x37\x3c\xbf\x8c\x2e\xf0\x2e\x39\x03\x25\xe1\x66\x3a\x99\x5d\xc3\x2f\x70\x73\x37\xfe\xfa\xe3\x64\xbe\x78\xb8\xff\xee\x6e\x32\xa6\x27\xd3\xf9\x6c\x3a\x9f\x3c\xc4\x17\xa7\x45\x32\x8f\xbc\x3a\x26\xcf\x1b\x45\x21\xa8\x20\xc7\xfd\x23\xaf\xaa\xff\x87\x18\x1f\x99\x7e\x74\x96\x43\x81\x8e\x19\x95\x03\x6b\x42\xd5\xa4\x05\x96\x8c\x1c\x40\x2a\x10\x4a\x92\x4f\x98\xba\xaa\x94\xb6\x98\xed\xf1\x9b\x51\x54\xa7\xcc\x62\x16\xb8\x1e\x78\x5d\x4f\x9e\x2b\xc1\xb8\x34\xb0\x2d\x76\x5e\x35\x91\xd8\x96\x19\x68\x6f\x0d\xa1\x36\x35\x13\x62\x07\x4c\x98\xe8\x1c\xe4\xa1\x0c\x4c\xbd\xd9\xa0\xa1\xac\xe9\x10\x29\xa0\x0a\xb5\x25\x7d\x93\x03\x1a\xd3\x72\x05\x86\x97\x9c\xd2\x31\x25\xb1\x11\xdc\x28\x5d\x32\x4b\x31\xc0\xfd\xd5\x1f\x49\xe2\x4c\x6d\xa5\xcf\xad\xe6\xf2\xe2\x22\x63\x14\x07\x39\xd7\xb8\x66\x42\x8c\x24\xda\x8b\x4a\x2b\x8a\x61\x73\x51\x86\xd3\x17\x67\xa3\x9e\x4c\x1a\x99\x51\xf2\x32\x44\x28\x7c\x05\x83\xf9\xbe\x86\x06\x1d\x93\x3c\x5c\x4f\x6e\xa6\xf3\xe9\x62\x7a\x3b\x87\x5f\x60\x7c\xf7\xf5\x92\x8c\xd0\x7f\x3a\x9d\x7f\xb7\x5c\x3c\x1c\x38\x3e\x99\x2f\x3f\x3e\xfc\x30\x9e\x2d\x27\xde\x6a\x93\xe7\x4a\x19\x2a\x1c\xb0\xbc\x9b\x81\x2d\x98\x6d\x0a\x88\xf7\xc8\x35\x16\xec\x89\x2b\x0d\x2a\xf7\x1a\xf7\x01\xb9\xef\x62\xb1\xec\x7c\xd8\x05\x9b\x2d\x0a\x7c\x2b\xa8\xb7\x72\xad\x45\x54\x47\x70\xc5\xfb\xab\xf1\x6c\x7c\xe7\x39\x9e\xca\x8c\x93\x6b\x18\xc0\x67\x96\x5a\xb1\x03\x25\x31\x84\x48\x59\x1b\x4b\xb9\x84\x6c\x28\xa8\x04\x52\xf9\xed\x84\x90\x7b\x4f\x39\x6f\x8d\xb0\x92\xb5\x10\xab\x3d\x39\x94\xc4\xdb\x9c\x74\xed\x55\x78\xfb\xe1\xdb\xc9\xd5\x2b\x81\x9a\xa1\xe0\x4f\xa8\x03\xa1\x18\xa2\x2e\x13\x9b\x7a\xed\xab\x9b\x85\x8a\xed\x84\x62\xd9\x5b\x43\x37\xc3\x1c\x75\xd0\xe8\x35\xfd\xad\x4f\x07\x2f\x7c\xe5\x92\x53\x48\x5a\xb1\xc8\x78\xb5\x1f\xe0\x26\x1a\x20\x66\x96\x00\x28\xd8\x1a\x1b\x23\x04\xdf\xfb\x6d\xd9\xac\x55\x12\x82\xe0\xc6\x02\xb7\x58\x1a\x60\xb9\x0d\x0f\x73\xae\x8d\x85\x15\x97\xdc\x72\x26\xae\x54\x4d\xad\x9a\x3f\xc4\xe5\x01\xd6\xcd\x5b\x35\x69\xac\x46\x56\x06\x55\xde\xbb\x1f\xbf\xa7\x2a\x4d\xe3\xcc\x24\xde\x41\x3d\x36\x61\x21\xeb\x72\x8d\x2e\xa4\x3a\xaa\xe8\x54\x31\xee\xeb\x54\xd0\x45\xa4\x10\x39\xec\x68\xe8\xd2\x75\xa2\x5f\xc1\x1f\x3b\xf9\xc1\xdb\x61\xc6\x4b\x6e\x0d\xd5\x7b\x97\x4c\x1c\x9e\x46\xa3\x04\x19\xc1\x71\x1a\x83\x86\xfa\x58\xcb\x1e\x71\xd8\x79\x46\xad\x41\x2d\x04\x6c\xb9\x2d\x80\xc1\x62\xfa\x71\x72\xbb\x5c\x00\x6a\xad\xb4\x57\x19\xb7\x90\x29\xa4\x74\x6e\x23\x2e\x35\x1f\x96\x97\xfb\x9a\xa7\x87\xaa\xb6\x41\xf5\x94\x17\xc2\x13\xba\x51\x72\x21\xb8\xc1\x54\xc9\xcc\x04\x09\x4b\xe3\xe4\xea\xd6\xa1\x4e\x16\xf3\xf2\x5d\x29\x99\xf3\x4d\xad\x83\x3d\x52\xdf\x25\x76\x44\x88\xed\x54\xaa\xca\xaa\xb6\x18\x4e\x95\x95\xc0\x67\x6e\x77\xa4\x7b\x55\xa1\x66\x54\x09\xcc\x1e\xc7\x04\xd7\x61\x77\x0f\x7d\x08\xdb\x82\xa7\x05\xf9\x1a\xcb\x42\xef\xd4\x65\x83\x54\x6f\xea\x35\x18\x14\xa4\x04\x22\xe1\x45\xdb\x22\xdf\x14\x8d\xd9\xbe\x68\x49\x44\xf7\x35\x3d\x3a\x3e\x79\x96\xb5\xb0\xbc\x12\x3b\x07\x4b\x24\xa2\x9e\xfc\x73\x8e\xda\x5c\xc2\x8f\x21\x63\x7e\x3a\xa5\xb5\x31\x5c\x37\x42\x56\x5a\x3d\xf1\xcc\xe5\xfe\x2d\xdb\x91\xaa\x32\x34\xa9\xe6\xd4\x8b\x09\x8b\x5a\x32\x8b\xa0\x6b\x49\xb6\x0a\xa1\x4c\x43\x87\x4b\xa9\xd4\x72\x3e\x31\xc1\x33\xa7\xbf\x36\x9f\x73\xd9\xa9\xf9\x99\x4a\x5d\x87\x35\x4a\x92\xa9\x04\xa3\x4a\x84\x94\x19\x34\x43\xd8\xa9\x1a\x24\x7a\xb5\x05\x36\x40\x55\x04\x65\xe8\x91\x23\x1f\x61\xfe\x60\x3a\xc4\x23\xa1\x84\x4b\xd8\xb2\x5d\xcc\xeb\xad\xf2\x5c\x37\x49\x3e\x69\xea\x3c\xe7\x29\x0e\x21\x36\x9e\xe4\x60\x9c\x48\xb8\xbe\xa0\x6d\x09\x94\x4e\xa8\x3d\xaa\xe8\x6f\xe6\xf1\x46\xad\x96\x4c\xc3\x9f\x0b\xed\xf5\x2e\x2a\xc9\x1d\xcf\x22\x22\x70\x49\xa3\x87\xd3\x46\x62\x55\x2f\xfb\x79\xe7\xa2\x66\x19\x1e\x1e\x5a\xf5\xff\xd7\x99\x50\xb2\x12\x63\x7e\x78\xe7\x9e\x78\x7c\xa7\x8c\x5e\xe2\x10\x2a\x75\xf0\x64\xea\x0e\xce\x2c\x3c\x7e\xf7\xc9\x5f\x67\x7a\x63\xde\x87\x3c\x72\xdd\x34\x44\xdd\x9c\xe6\x46\x98\x33\x87\x32\xa5\x3e\xfc\x07\x6a\xc3\xe3\x75\x6e\xee\xb0\x42\x66\x69\x72\x6a\x2e\xbd\x4b\x7e\xdd\x77\x1f\x4a\x1b\xe4\x2a\xd9\x4f\x2c\xa5\xb4\x6c\x15\x94\x34\x0b\x54\x4c\xb7\x4e\x1c\x5d\x41\x30\xb9\xa9\xd9\x06\x87\xc0\x92\x03\xcc\x37\x9e\x67\x5c\x31\x77\x26\xab\x94\x31\x7c\x2d\x1a\x0a\x29\xc7\x10\xa7\x28\xeb\x12\x0e\xa1\x78\x95\x0e\x9a\xdf\x5d\xde\x18\xfc\x5c\xa3\xde\xb5\x41\x1f\x62\xe8\xfb\xe5\xe4\xee\xdf\x27\xef\x95\xb5\xf5\x4c\xbe\xbc\xfa\x71\xb9\x18\xbb\xd0\x3a\x75\x9b\x0a\x45\xb4\xe7\x1e\xc2\xfd\xf2\xc3\xfd\xd5\xdd\xf4\xbb\x57\x51\xbc\x5b\x7a\xb2\x2e\xb2\x4f\x9f\x8e\x3d\x48\x86\xb9\xab\x21\x0d\xc1\x58\xab\xbb\x89\xe1\xb3\x90\x4c\x45\xf3\xf4\x4b\x14\xdf\x0a\x9c\x42\xa0\x59\x51\x70\x89\x0d\x52\x80\x78\xd9\x3b\x9c\x62\x22\xcc\x0e\x7b\xc2\xdc\x5f\x7d\x33\xf9\x38\x7e\xe5\x2a\x35\xab\xfb\x7a\x08\x0d\xe5\x89\xab\xcd\xf0\xed\x02\x77\x0f\x20\xf4\x85\xa7\x68\x3b\x9b\x1d\x30\xc1\xcb\xc4\x7c\x92\x89\x98\xd9\xf6\x71\x0e\x34\xfe\xa7\xa1\x68\x14\xd7\x39\x4b\x0f\x48\x33\x9d\x2f\x26\x77\x37\xe3\xab\xc9\x29\x04\xda\x5c\x28\xb9\x7f\x79\x39\x7f\x95\xb4\x8b\xd8\xbd\x8b\x34\x8b\x7c\xc6\x3d\xbf\x26\x38\x78\x3b\x4c\x32\x27\x31\xfc\xae\xe1\xa4\x31\x7b\xad\xfe\xe7\x83\x1d\x31\xf0\xe1\xd9\x2b\xa6\xd0\x5b\x89\x6d\x6a\xf3\xa2\xd1\x0c\xca\x60\xc3\x9f\x50\xc2\x44\xd6\xe5\xc8\xfd\x37\xec\x1a\x81\x69\x8c\x3b\x23\xff\x64\xe8\x7a\x2e\x96\x54\x82\xa5\x58\x28\x91\xa1\x0e\x10\x61\x0d\xa3\x74\x7f\x5f\x39\x82\x6f\xfc\xd6\x87\x54\xda\x42\x53\xf3\xa2\xd1\xd6\x9a\xd6\x9c\x5c\x26\x07\xf7\x46\x1e\xb2\x57\xc2\x08\xc1\xd5\x8c\x37\x97\x30\x6e\x0e\x54\xa4\x78\xc5\xbf\xe0\x4a\xde\xf5\x06\xe2\x46\x6f\x5e\xe7\xd4\x7d\x4c\x1b\x47\x26\x8e\xbc\x86\x62\x01\x71\xfb\x41\xe6\x27\x0c\x95\xc3\x0d\x99\xc8\x0c\x01\x59\x5a\x50\x4d\xf2\x5d\x5b\xc1\x4c\xc2\x1c\xdf\x43\xa8\x94\xa5\x19\xc9\x35\x05\xed\xbd\xa6\xa1\xa0\xfd\x56\x06\x2c\x28\xca\xf9\x4f\x4f\x19\x8e\xc0\x9b\x15\xf1\x1b\x8b\x34\xd1\xbe\x84\x87\x87\xc5\xae\xc2\x77\xbf\x59\xb3\xe3\x28\x6b\x6c\x75\x5c\x5b\xe6\x04\x33\xb4\x44\x6b\xea\xac\x09\xe3\x34\x86\x30\xc8\xc3\x91\x1c\x98\x4c\x5c\x2b\x11\x8d\xa4\x3b\x3b\x4f\x9a\xc2\x0d\xb8\xd7\xe0\xa4\x30\xc1\x0a\xd1\x64\xd4\x2c\xf1\xb0\x29\x65\x32\x4b\x7c\x23\x18\x0c\x92\x61\xce\x6a\x11\x76\x85\x3d\xcd\xb7\x6a\x79\xb3\xfa\xf7\xf5\x37\x18\xc7\x76\xd3\xad\x90\xfd\x9e\x27\x84\x53\x23\x09\x0f\x63\x54\x8f\x27\x17\x7a\xae\x37\xec\x2c\x35\x43\x26\x08\x07\x9d\xd4\xbf\x5b\x20\x34\x9c\xc2\x7d\xa7\x2e\xc6\x31\x88\x55\x6c\xcd\x05\xb7\x34\xa7\xf6\x17\x70\xa8\x9f\x50\x8f\x60\x6a\x69\x99\xeb\x77\x3e\x42\x24\xec\x89\x71\x41\xcd\x5e\x8c\x25\x99\x41\x33\x07\x51\x37\xe6\x60\xfd\xe5\x21\x19\x72\x8b\x42\xd0\xff\xe9\x31\x4a\xab\x77\xe0\xbe\x16\x18\xd2\x43\xe2\x5a\xac\x61\xd3\x31\xf9\xe0\x39\xdc\x01\x99\x9e\x31\x83\x28\xa1\x81\x1b\xb7\x71\x28\x44\x60\xac\x5d\xcb\xad\xc3\xea\x2f\x48\x34\x68\x4c\xea\x3b\x63\x67\xd4\x10\x28\x6e\x78\xa3\x57\x7e\x8e\x7a\xd1\x01\x86\x69\x61\x8d\xa0\x95\x22\x64\x16\x07\x2b\x77\x70\xb1\xef\x25\xd3\x30\xe1\x7b\xd2\x71\x3d\x67\x3a\x02\xdb\x1e\xc1\xf8\xfc\x73\x68\xc6\xb3\x5d\xb2\xa7\xa8\xf6\xf4\xfa\x92\xf0\x11\x9d\x1f\x25\xde\x3d\xbf\xcf\x40\xdf\x1e\x1d\xf7\x78\xc5\x28\xed\xc9\xfe\xcc\x42\xc3\xc6\xaf\xed\xf7\x92\xbc\x96\x19\xa3\x6c\xcb\x04\x55\x3a\x6f\x78\xb9\x7b\xe9\xe7\xdc\x34\x42\x8e\x60\x51\xa0\x46\x97\xf9\xdd\xd0\xf1\xc8\xa5\xcb\x44\xce\x9f\xdc\xc2\x28\x5e\x66\xa6\x97\x8a\x1c\x9f\x08\x2b\x2f\xde\x3f\xb9\xcc\x56\xae\xcb\x18\x25\xc9\x35\x56\x28\x33\x0a\xf2\xe0\xf5\x04\xea\x78\x09\x5f\x3a\x52\xd4\x96\x71\x19\xf3\x5e\x93\xbf\x3a\xb3\x1f\xb0\x35\xad\x35\xc8\xdb\x1c\x2b\x23\xb8\x6f\xbf\x03\xb5\xb3\xa4\x54\xdd\x81\x11\xd6\xb8\x53\xae\xca\x50\x49\x72\x31\xd3\xc9\x5c\x6e\xd5\x20\x30\xa1\xb2\xfb\x02\xc6\x27\xce\xf8\x4d\xf2\x44\x85\xec\x5c\x08\xdc\x27\xb6\xc0\x66\x96\xc5\x11\x8c\xd7\xc6\xd2\xe7\x38\x7f\x61\x08\x4b\x19\x67\xfd\x06\x6b\xd8\xa5\x1b\xa9\xb9\xe3\x49\x3b\xab\xd9\xb8\x31\x18\xc1\x8c\x62\x98\x84\x99\x2b\x39\xaf\x9b\x40\xa6\x1d\x8c\x32\x08\xca\x16\x18\xf4\xd2\x4b\x05\x64\x97\x90\xd1\xc9\x00\xd1\x13\xc9\x54\xef\xf6\xf2\x7c\x48\x99\x8d\xb2\x7a\xcf\xbd\xa4\x9f\x5d\x67\x5d\xc5\x7b\xf7\x29\xec\xd8\x82\xd0\xdd\x9c\xe2\xde\x44\x51\x17\x2f\x12\x8e\x7b\x89\xb1\x35\xfa\x7c\xaa\x4d\x37\x15\x20\x5c\x15\xb9\x79\x1b\xe7\xdd\x0e\xc1\x81\xa8\x7c\x2f\x88\x3b\xfb\xf9\xe5\xdd\xac\xa7\x27\x6e\x6e\x69\xe9\xdd\x80\xc7\xd8\x1c\x37\x8d\x7b\xb3\xf1\xd8\x52\x72\x89\x71\xe1\x0c\x16\x1b\xd7\x10\x51\x2b\xe0\xfd\x29\x3d\xda\x2e\x98\x74\xd0\xae\xef\x5d\x26\x8d\x5f\x79\xe3\xa8\x76\x60\x3e\x3b\x76\x23\x4e\x68\x23\x58\x79\x4b\xaf\x9c\xbf\xae\x5a\xdb\xd1\x52\x38\xec\xa9\x82\xdb\x1f\x98\xde\x8e\xc3\x37\x40\x2d\x85\x61\x0f\xde\xd7\xb7\x55\xcf\x25\x8e\xd2\x7c\x31\x63\x1d\x23\x4b\x19\x50\xc9\x11\xbc\x00\x75\xaf\x3a\xa8\xfb\xb3\xd7\x71\x39\xc8\x88\x23\x58\xb5\xce\x79\x14\xae\x9d\xc8\x8e\xa3\x75\x47\xa0\x11\xac\x3a\xfe\x7a\x14\x76\x7f\xbe\x3a\x06\xef\x0a\xcd\x08\x56\xde\x81\x8f\x02\xce\xa6\xf7\xaf\x01\xd1\xbf\xe6\xa0\x2f\x3c\xaf\x83\xcd\x6f\xe7\x0f\xf3\xe5\x6c\x96\xfc\x9a\xfc\x6f\x00\x0f\xb1\x8d\xe7\x28\x22\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
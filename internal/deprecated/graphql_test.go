@@ -1174,7 +1174,7 @@ func TestIntrospection(t *testing.T) {
 					"b": {
 						"name": "Character",
 						"kind": "INTERFACE",
-						"interfaces": null,
+						"interfaces": [],
 						"possibleTypes": [
 							{
 								"name": "Droid"
@@ -1359,6 +1359,60 @@ func TestIntrospection(t *testing.T) {
                 {
                   "__schema": {
                     "directives": [
+                      {
+                        "args": [
+                          {
+                            "description": "The arguments of the field that multiply its cost.",
+                            "name": "multipliers",
+                            "type": {
+                              "kind": "LIST",
+                              "ofType": {
+                                "kind": "NON_NULL",
+                                "name": null
+                              }
+                            }
+                          },
+                          {
+                            "description": "The cost of the field, which is added to the cost of its sub selections.",
+                            "name": "weight",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          }
+                        ],
+                        "description": "\nConfigures the cost of the field used to compute the complexity of operations.\n",
+                        "locations": [
+                          "FIELD_DEFINITION"
+                        ],
+                        "name": "cost"
+                      },
+                      {
+                        "args": [
+                          {
+                            "description": "Deferred when true.",
+                            "name": "if",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          },
+                          {
+                            "description": "Identifies the subsequent payload of this fragment.",
+                            "name": "label",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          }
+                        ],
+                        "description": "\nDirects the executor to deliver this fragment in a subsequent payload when the ` + "`if`" + ` argument is true.\n",
+                        "locations": [
+                          "FRAGMENT_SPREAD",
+                          "INLINE_FRAGMENT"
+                        ],
+                        "name": "defer"
+                      },
                       {
                         "args": [
                           {
@@ -1373,6 +1427,8 @@ func TestIntrospection(t *testing.T) {
                         "description": "\nMarks an element of a GraphQL schema as no longer supported.\n",
                         "locations": [
                           "FIELD_DEFINITION",
+                          "ARGUMENT_DEFINITION",
+                          "INPUT_FIELD_DEFINITION",
                           "ENUM_VALUE"
                         ],
                         "name": "deprecated"
@@ -1399,6 +1455,14 @@ func TestIntrospection(t *testing.T) {
                         ],
                         "name": "include"
                       },
+                      {
+                        "args": [],
+                        "description": "\nIndicates exactly one field must be supplied and this field must not be ` + "`null`" + `.\n",
+                        "locations": [
+                          "INPUT_OBJECT"
+                        ],
+                        "name": "oneOf"
+                      },
                       {
                         "args": [
                           {
@@ -1420,6 +1484,79 @@ func TestIntrospection(t *testing.T) {
                           "INLINE_FRAGMENT"
                         ],
                         "name": "skip"
+                      },
+                      {
+                        "args": [
+                          {
+                            "description": "The URL that specifies the behavior of this scalar.",
+                            "name": "url",
+                            "type": {
+                              "kind": "NON_NULL",
+                              "ofType": {
+                                "kind": "SCALAR",
+                                "name": "String"
+                              }
+                            }
+                          }
+                        ],
+                        "description": "\nExposes a URL that specifies the behavior of this scalar.\n",
+                        "locations": [
+                          "SCALAR"
+                        ],
+                        "name": "specifiedBy"
+                      },
+                      {
+                        "args": [
+                          {
+                            "description": "Streamed when true.",
+                            "name": "if",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          },
+                          {
+                            "description": "The number of list items to include in the initial payload.",
+                            "name": "initialCount",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          },
+                          {
+                            "description": "Identifies the subsequent payloads of this list.",
+                            "name": "label",
+                            "type": {
+                              "kind": "SCALAR",
+                              "ofType": null
+                            }
+                          }
+                        ],
+                        "description": "\nDirects the executor to deliver the list items after the first ` + "`initialCount`" + ` items in subsequent payloads when the ` + "`if`" + ` argument is true.\n",
+                        "locations": [
+                          "FIELD"
+                        ],
+                        "name": "stream"
+                      },
+                      {
+                        "args": [
+                          {
+                            "description": "The timeout in milliseconds.",
+                            "name": "ms",
+                            "type": {
+                              "kind": "NON_NULL",
+                              "ofType": {
+                                "kind": "SCALAR",
+                                "name": "Int"
+                              }
+                            }
+                          }
+                        ],
+                        "description": "\nLimits how long the resolver of the field can take, the field is null with a TIMEOUT error when it does not resolve in time.\n",
+                        "locations": [
+                          "FIELD_DEFINITION"
+                        ],
+                        "name": "timeout"
                       }
                     ]
                   }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/chirino/graphql/exec"
 	"github.com/chirino/graphql/internal/introspection"
@@ -31,9 +32,12 @@ type Execution struct {
 	VarTypes  map[string]*introspection.Type
 	Context   context.Context
 	Resolver  resolvers.Resolver
-	Mu        sync.Mutex
+	// Middleware is called around the Resolver of every field, the first one is the outermost.
+	Middleware []resolvers.Middleware
+	Mu         sync.Mutex
 
 	rootFields     *linkedmap.LinkedMap
+	values         *sync.Map
 	data           *bytes.Buffer
 	errs           qerrors.ErrorList
 	MaxParallelism int
@@ -42,6 +46,19 @@ type Execution struct {
 	FireSubscriptionEventFunc func(d json.RawMessage, e qerrors.ErrorList)
 	FireSubscriptionCloseFunc func()
 	TryCast                   func(value reflect.Value, toType string) (v reflect.Value, ok bool)
+
+	// FireIncrementalEventFunc enables the @defer and @stream directives.  When set, the initial
+	// and subsequent payloads are sent to it instead of FireSubscriptionEventFunc.
+	FireIncrementalEventFunc func(d json.RawMessage, e qerrors.ErrorList, path []interface{}, label string, hasNext bool)
+	incremental              []*incrementalPart
+	// nulled holds the paths that were written as null because of an error, the delayed parts
+	// below them are dropped.
+	nulled [][]interface{}
+
+	// Timeout limits how long a query or mutation can execute.  The fields that have not
+	// been resolved once it expires are null with a TIMEOUT error.
+	Timeout       time.Duration
+	cancelTimeout context.CancelFunc
 }
 
 func (this *Execution) GetRoot() interface{} {
@@ -74,6 +91,9 @@ func (this *Execution) GetContext() context.Context {
 func (this *Execution) GetLimiter() *chan byte {
 	return &this.limiter
 }
+func (this *Execution) GetValues() *sync.Map {
+	return this.values
+}
 func (this *Execution) HandlePanic(path []string) error {
 	if value := recover(); value != nil {
 		this.Logger.LogPanic(this.Context, value)
@@ -98,19 +118,25 @@ type SelectionResolver struct {
 	field      *schema.FieldSelection
 	Resolution resolvers.Resolution
 	selections []schema.Selection
+	// path is the response path of the field, including list indexes.
+	path []interface{}
 }
 
+// Path returns the response path of the field, list indexes included.
 func (this *SelectionResolver) Path() []string {
 	if this == nil {
 		return []string{}
 	}
+	if this.path != nil {
+		return pathStrings(this.path)
+	}
 	if this.parent == nil {
 		return []string{this.field.Alias}
 	}
 	return append(this.parent.Path(), this.field.Alias)
 }
 
-func (this *Execution) resolveFields(ctx context.Context, parentSelectionResolver *SelectionResolver, selectionResolvers *linkedmap.LinkedMap, parentValue reflect.Value, parentType schema.Type, selections []schema.Selection) {
+func (this *Execution) resolveFields(ctx context.Context, objectPath []interface{}, parentSelectionResolver *SelectionResolver, selectionResolvers *linkedmap.LinkedMap, parentValue reflect.Value, parentType schema.Type, selections []schema.Selection) {
 	for _, selection := range selections {
 		switch field := selection.(type) {
 		case *schema.FieldSelection:
@@ -129,6 +155,7 @@ func (this *Execution) resolveFields(ctx context.Context, parentSelectionResolve
 				sr = &SelectionResolver{}
 				sr.field = field
 				sr.parent = parentSelectionResolver
+				sr.path = appendPath(objectPath, field.Alias)
 			}
 
 			if sr.Resolution == nil {
@@ -136,35 +163,24 @@ func (this *Execution) resolveFields(ctx context.Context, parentSelectionResolve
 				// This field has not been resolved yet..
 				sr.selections = field.Selections
 
-				typeName := parentType
-				evaluatedArguments := make(map[string]interface{}, len(field.Arguments))
-				for _, arg := range field.Arguments {
-					evaluatedArguments[arg.Name] = arg.Value.Evaluate(this.Vars)
-				}
-				resolveRequest := &resolvers.ResolveRequest{
-					Context:          ctx,
-					ExecutionContext: this,
-					ParentType:       typeName,
-					Parent:           parentValue,
-					Field:            field.Schema.Field,
-					Args:             evaluatedArguments,
-					Selection:        field,
-					SelectionPath:    sr.Path,
+				// the root fields of mutations are resolved one after the other in document order.
+				if parentSelectionResolver == nil && this.Operation.Type == schema.Mutation {
+					sr.Resolution = this.serialResolution(ctx, sr, parentValue, parentType)
+					selectionResolvers.Set(field.Alias, sr)
+					continue
 				}
-				resolution := this.Resolver.Resolve(resolveRequest, nil)
 
+				resolution := this.resolveField(ctx, sr, parentValue, parentType, false)
 				if resolution == nil {
-					this.AddError((&qerrors.Error{
-						Message: "No resolver found",
-						Path:    append(parentSelectionResolver.Path(), field.Alias),
-					}).WithStack())
+					this.AddError(noResolverError(sr))
 				} else {
 					sr.Resolution = resolution
 					selectionResolvers.Set(field.Alias, sr)
 				}
 			} else {
 				// field previously resolved, but fragment is adding more child field selections.
-				sr.selections = append(sr.selections, field.Selections...)
+				// limit the capacity so that append copies instead of writing into the document's slice.
+				sr.selections = append(sr.selections[:len(sr.selections):len(sr.selections)], field.Selections...)
 			}
 
 		case *schema.InlineFragment:
@@ -173,29 +189,107 @@ func (this *Execution) resolveFields(ctx context.Context, parentSelectionResolve
 			}
 
 			fragment := &field.Fragment
-			this.CreateSelectionResolversForFragment(ctx, parentSelectionResolver, fragment, parentType, parentValue, selectionResolvers)
+			if label, ok := this.deferByDirective(field.Directives); ok {
+				this.deferFragment(ctx, objectPath, label, parentSelectionResolver, fragment, parentType, parentValue)
+				continue
+			}
+			this.CreateSelectionResolversForFragment(ctx, objectPath, parentSelectionResolver, fragment, parentType, parentValue, selectionResolvers)
 
 		case *schema.FragmentSpread:
 			if this.skipByDirective(field.Directives) {
 				continue
 			}
 			fragment := &this.Doc.Fragments.Get(field.Name).Fragment
-			this.CreateSelectionResolversForFragment(ctx, parentSelectionResolver, fragment, parentType, parentValue, selectionResolvers)
+			if label, ok := this.deferByDirective(field.Directives); ok {
+				this.deferFragment(ctx, objectPath, label, parentSelectionResolver, fragment, parentType, parentValue)
+				continue
+			}
+			this.CreateSelectionResolversForFragment(ctx, objectPath, parentSelectionResolver, fragment, parentType, parentValue, selectionResolvers)
 		}
 	}
 }
 
-func (this *Execution) CreateSelectionResolversForFragment(ctx context.Context, parentSelectionResolver *SelectionResolver, fragment *schema.Fragment, parentType schema.Type, parentValue reflect.Value, selectionResolvers *linkedmap.LinkedMap) {
-	if fragment.On.Name != "" && fragment.On.Name != parentType.String() {
+// resolveField asks the Resolver for the Resolution of the field.
+func (this *Execution) resolveField(ctx context.Context, sr *SelectionResolver, parentValue reflect.Value, parentType schema.Type, serial bool) resolvers.Resolution {
+	field := sr.field
+	evaluatedArguments := make(map[string]interface{}, len(field.Arguments))
+	for _, arg := range field.Arguments {
+		evaluatedArguments[arg.Name] = arg.Value.Evaluate(this.Vars)
+	}
+	if err := checkOneOfArgs(field.Schema.Field.Args, evaluatedArguments); err != nil {
+		return errorResolution(err)
+	}
+	fieldCtx, cancel := this.fieldContext(ctx, field.Schema.Field)
+	resolveRequest := &resolvers.ResolveRequest{
+		Context:          fieldCtx,
+		ExecutionContext: this,
+		ParentType:       parentType,
+		Parent:           parentValue,
+		Field:            field.Schema.Field,
+		Args:             evaluatedArguments,
+		Selection:        field,
+		SelectionPath:    sr.Path,
+		Serial:           serial,
+	}
+	// the middleware runs before the Resolver, so it can fail the field before a resolver starts working on it.
+	deadline := false
+	resolution := resolvers.ResolveWithMiddleware(this.Middleware, resolveRequest, func() resolvers.Resolution {
+		resolution := this.Resolver.Resolve(resolveRequest, nil)
+		if resolution != nil && (cancel != nil || this.Timeout > 0) {
+			deadline = true
+			resolution = this.withDeadline(fieldCtx, cancel, sr.Path, resolution)
+		}
+		return resolution
+	})
+	if !deadline && cancel != nil {
+		cancel()
+	}
+	return resolution
+}
+
+// serialResolution delays asking the Resolver for the Resolution of the field until it gets executed,
+// so that resolvers which start working eagerly (like RunAsync does) can't run concurrently with the
+// fields that come before it.
+func (this *Execution) serialResolution(ctx context.Context, sr *SelectionResolver, parentValue reflect.Value, parentType schema.Type) resolvers.Resolution {
+	return func() (reflect.Value, error) {
+		resolution := this.resolveField(ctx, sr, parentValue, parentType, true)
+		if resolution == nil {
+			return reflect.Value{}, noResolverError(sr)
+		}
+		return resolution()
+	}
+}
+
+func noResolverError(sr *SelectionResolver) *qerrors.Error {
+	return (&qerrors.Error{
+		Message: "No resolver found",
+		Path:    sr.Path(),
+	}).WithStack()
+}
+
+func (this *Execution) CreateSelectionResolversForFragment(ctx context.Context, objectPath []interface{}, parentSelectionResolver *SelectionResolver, fragment *schema.Fragment, parentType schema.Type, parentValue reflect.Value, selectionResolvers *linkedmap.LinkedMap) {
+	if fragment.On.Name != "" && fragment.On.Name != parentType.String() && !implements(parentType, fragment.On.Name) {
 		castType := this.Schema.Types[fragment.On.Name]
 		if casted, ok := this.TryCast(parentValue, fragment.On.Name); ok {
-			this.resolveFields(ctx, parentSelectionResolver, selectionResolvers, casted, castType, fragment.Selections)
+			this.resolveFields(ctx, objectPath, parentSelectionResolver, selectionResolvers, casted, castType, fragment.Selections)
 		}
 	} else {
-		this.resolveFields(ctx, parentSelectionResolver, selectionResolvers, parentValue, parentType, fragment.Selections)
+		this.resolveFields(ctx, objectPath, parentSelectionResolver, selectionResolvers, parentValue, parentType, fragment.Selections)
 	}
 }
 
+// implements reports if the type implements the named interface, in which case its values don't need a cast.
+func implements(t schema.Type, interfaceName string) bool {
+	var interfaces schema.InterfaceList
+	switch t := t.(type) {
+	case *schema.Object:
+		interfaces = t.Interfaces
+	case *schema.Interface:
+		interfaces = t.Interfaces
+	}
+	return interfaces.Get(interfaceName) != nil
+}
+
 func (this *Execution) Execute() error {
 
 	rootType := this.Schema.EntryPoints[this.Operation.Type]
@@ -204,9 +298,15 @@ func (this *Execution) Execute() error {
 
 	// async processing can start when the field is selected... apply limit here...
 	this.errs = qerrors.ErrorList{}
+	this.values = &sync.Map{}
 	this.limiter = make(chan byte, this.MaxParallelism)
 	this.limiter <- 1
-	this.resolveFields(this.Context, nil, rootFields, rootValue, rootType, this.Operation.Selections)
+	this.incremental = nil
+	this.nulled = nil
+	if this.Timeout > 0 && this.Operation.Type != schema.Subscription {
+		this.Context, this.cancelTimeout = context.WithTimeout(this.Context, this.Timeout)
+	}
+	this.resolveFields(this.Context, []interface{}{}, nil, rootFields, rootValue, rootType, this.Operation.Selections)
 
 	if this.Operation.Type == schema.Subscription {
 
@@ -234,8 +334,19 @@ func (this *Execution) Execute() error {
 		// This is the first execution goroutine.
 		// TODO: this may need to move for the subscription case..
 		this.data = &bytes.Buffer{}
+		this.writeRoot(rootFields)
+		this.dropNulledParts()
+		if len(this.incremental) > 0 {
+			this.FireIncrementalEventFunc(json.RawMessage(this.data.Bytes()), this.errs, nil, "", true)
+			// deliver the deferred payloads async so that the caller can start consuming the initial payload.
+			go func() {
+				defer func() { <-this.limiter }()
+				this.executeIncremental()
+				this.FireSubscriptionClose()
+			}()
+			return nil
+		}
 		defer func() { <-this.limiter }()
-		this.recursiveExecute(this.Context, nil, rootFields)
 		this.FireSubscriptionEventFunc(json.RawMessage(this.data.Bytes()), this.errs)
 		this.FireSubscriptionClose()
 		return nil
@@ -269,10 +380,11 @@ func (this *Execution) FireSubscriptionEvent(value reflect.Value, err error) {
 	if err != nil {
 		this.errs = qerrors.AppendErrors(this.errs, err)
 	} else {
+		this.values = &sync.Map{}
 		this.limiter = make(chan byte, this.MaxParallelism)
 		this.limiter <- 1
 		defer func() { <-this.limiter }()
-		this.recursiveExecute(this.Context, nil, this.rootFields)
+		this.writeRoot(this.rootFields)
 	}
 
 	this.FireSubscriptionEventFunc(this.data.Bytes(), this.errs)
@@ -281,12 +393,26 @@ func (this *Execution) FireSubscriptionEvent(value reflect.Value, err error) {
 func (this *Execution) FireSubscriptionClose() {
 	this.subMu.Lock()
 	defer this.subMu.Unlock()
+	if this.cancelTimeout != nil {
+		this.cancelTimeout()
+	}
 	this.FireSubscriptionCloseFunc()
 	this.FireSubscriptionEventFunc = nil
 	this.FireSubscriptionCloseFunc = nil
 }
 
-func (this *Execution) recursiveExecute(ctx context.Context, parentSelection *SelectionResolver, selectedFields *linkedmap.LinkedMap) { // (parentSelection *SelectionResolver, parentValue reflect.Value, parentType schema.Type, selections []query.Selection) ExecutionResult {
+// writeRoot writes the data of the operation, which is null when a non null root field is null.
+func (this *Execution) writeRoot(rootFields *linkedmap.LinkedMap) {
+	if !this.recursiveExecute(this.Context, nil, rootFields) {
+		this.data.Reset()
+		this.data.WriteString("null")
+		this.nulled = append(this.nulled, []interface{}{})
+	}
+}
+
+// recursiveExecute writes the selected fields of an object.  Errors on nullable fields make them
+// null, but it returns false when a non null field errors, since the whole object has to be null then.
+func (this *Execution) recursiveExecute(ctx context.Context, parentSelection *SelectionResolver, selectedFields *linkedmap.LinkedMap) bool {
 
 	this.data.WriteByte('{')
 	writeComma := false
@@ -304,12 +430,24 @@ func (this *Execution) recursiveExecute(ctx context.Context, parentSelection *Se
 		if err != nil {
 			// undo any (likely partial) writes that we performed
 			this.data.Truncate(offset)
-			this.AddError(err)
-			continue
+			if err != errNullPropagated {
+				this.AddError(err)
+			}
+			if _, nonNull := selected.field.Schema.Field.Type.(*schema.NonNull); nonNull {
+				return false
+			}
+			if writeComma {
+				this.data.WriteByte(',')
+			}
+			this.data.WriteByte('"')
+			this.data.WriteString(selected.field.Alias)
+			this.data.WriteString(`":null`)
+			this.nulled = append(this.nulled, selected.path)
 		}
 		writeComma = true
 	}
 	this.data.WriteByte('}')
+	return true
 }
 
 var rawMessageType = reflect.TypeOf(resolvers.RawMessage{})
@@ -328,10 +466,15 @@ func (this *Execution) executeSelected(ctx context.Context, parentSelection *Sel
 
 	childValue, err := selected.Resolution()
 	if err != nil {
-		if err, ok := err.(*qerrors.Error); ok {
-			return err.WithPath(selected.Path()...)
+		var qerr *qerrors.Error
+		if e, ok := err.(*qerrors.Error); ok {
+			// copy it, resolvers and middleware can return the same error for many fields.
+			copied := *e
+			qerr = copied.WithPath(selected.Path()...)
+		} else {
+			qerr = qerrors.WrapError(err, err.Error()).WithPath(selected.Path()...).WithStack()
 		}
-		return qerrors.WrapError(err, err.Error()).WithPath(selected.Path()...).WithStack()
+		return qerr
 	}
 
 	if childValue.IsValid() {
@@ -362,7 +505,7 @@ func (this *Execution) executeSelected(ctx context.Context, parentSelection *Sel
 	childType, nonNullType := unwrapNonNull(field.Schema.Field.Type)
 	valid := childValue.IsValid()
 
-	if !valid || ((childValue.Kind() == reflect.Ptr || childValue.Kind() == reflect.Interface) && childValue.IsNil()) {
+	if !valid || isNull(childValue) {
 		if nonNullType {
 			return (&qerrors.Error{
 				Message: "ResolverFactory produced a nil value for a Non Null type",
@@ -374,27 +517,61 @@ func (this *Execution) executeSelected(ctx context.Context, parentSelection *Sel
 		}
 	}
 
+	if listType, ok := childType.(*schema.List); ok {
+		if label, initialCount, ok := this.streamByDirective(field.Directives); ok {
+			if !this.writeStream(ctx, selected, *listType, childValue, label, initialCount) {
+				return errNullPropagated
+			}
+			return
+		}
+	}
+
 	// Are we a leaf node?
 	if selected.selections == nil {
-		this.writeLeaf(childValue, selected, childType)
+		if !this.writeLeaf(childValue, selected, childType) {
+			return errNullPropagated
+		}
 	} else {
 
 		switch childType := childType.(type) {
 		case *schema.List:
-			this.writeList(*childType, childValue, selected, func(elementType schema.Type, element reflect.Value) {
-				selectedFields := linkedmap.CreateLinkedMap(len(this.Operation.Selections))
-				this.resolveFields(ctx, selected, selectedFields, element, elementType, selected.selections)
-				this.recursiveExecute(ctx, selected, selectedFields)
-			})
+			if !this.writeObjectList(ctx, selected, *childType, childValue, selected.path) {
+				return errNullPropagated
+			}
 		case *schema.Object, *schema.Interface, *schema.Union:
 			selectedFields := linkedmap.CreateLinkedMap(len(this.Operation.Selections))
-			this.resolveFields(ctx, selected, selectedFields, childValue, childType, selected.selections)
-			this.recursiveExecute(ctx, selected, selectedFields)
+			this.resolveFields(ctx, selected.path, selected, selectedFields, childValue, childType, selected.selections)
+			if !this.recursiveExecute(ctx, selected, selectedFields) {
+				return errNullPropagated
+			}
 		}
 	}
 	return
 }
 
+// errNullPropagated is returned for fields that are null because a non null child field
+// or list element is null.  The error of the child has already been added.
+var errNullPropagated = &qerrors.Error{Message: "null propagated"}
+
+// isNull reports if a resolved value is null.  Nil slices are empty lists.
+func isNull(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+func (this *Execution) nilForNonNull(path []interface{}) *qerrors.Error {
+	return (&qerrors.Error{
+		Message: "ResolverFactory produced a nil value for a Non Null type",
+		Path:    pathStrings(path),
+	}).WithStack()
+}
+
 func (this *Execution) skipByDirective(directives schema.DirectiveList) bool {
 	skip, err := exec.SkipByDirective(directives, this.Vars)
 	if err != nil {
@@ -403,8 +580,7 @@ func (this *Execution) skipByDirective(directives schema.DirectiveList) bool {
 	return skip
 }
 
-func (this *Execution) writeList(listType schema.List, childValue reflect.Value, selectionResolver *SelectionResolver, writeElement func(elementType schema.Type, element reflect.Value)) {
-
+func dereferenceList(childValue reflect.Value) reflect.Value {
 	// Dereference pointers..
 	for childValue.Kind() == reflect.Ptr {
 		childValue = childValue.Elem()
@@ -413,24 +589,93 @@ func (this *Execution) writeList(listType schema.List, childValue reflect.Value,
 	for childValue.Kind() == reflect.Interface {
 		childValue = childValue.Elem()
 	}
+	return childValue
+}
+
+// writeObjectList writes a (possibly nested) list of objects.  It returns false if the list is null
+// because a non null element is null.
+func (this *Execution) writeObjectList(ctx context.Context, selected *SelectionResolver, listType schema.List, childValue reflect.Value, path []interface{}) bool {
+	// Resolve the fields of all the elements before executing any of them, so that
+	// resolvers get a chance to batch the work of sibling elements.
+	var elements []*linkedmap.LinkedMap
+	this.visitList(listType, childValue, path, func(elementType schema.Type, element reflect.Value, elementPath []interface{}) {
+		selectedFields := linkedmap.CreateLinkedMap(len(this.Operation.Selections))
+		this.resolveFields(ctx, elementPath, selected, selectedFields, element, elementType, selected.selections)
+		elements = append(elements, selectedFields)
+	})
+	next := 0
+	return this.writeList(listType, childValue, path, func(elementType schema.Type, element reflect.Value, elementPath []interface{}) bool {
+		selectedFields := elements[next]
+		next++
+		return this.recursiveExecute(ctx, selected, selectedFields)
+	})
+}
+
+// visitList calls visitElement for all the non null elements of a (possibly nested) list without writing anything.
+func (this *Execution) visitList(listType schema.List, childValue reflect.Value, path []interface{}, visitElement func(elementType schema.Type, element reflect.Value, elementPath []interface{})) {
+	childValue = dereferenceList(childValue)
+	switch childValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		elementType, _ := unwrapNonNull(listType.OfType)
+		l := childValue.Len()
+		for i := 0; i < l; i++ {
+			element := childValue.Index(i)
+			if isNull(element) {
+				continue
+			}
+			switch elementType := elementType.(type) {
+			case *schema.List:
+				this.visitList(*elementType, element, appendPath(path, i), visitElement)
+			default:
+				visitElement(elementType, element, appendPath(path, i))
+			}
+		}
+	}
+}
+
+// writeList writes a (possibly nested) list using writeElement for the non null elements.  Null elements
+// make the list null when they are not allowed, in which case it returns false.
+func (this *Execution) writeList(listType schema.List, childValue reflect.Value, path []interface{}, writeElement func(elementType schema.Type, element reflect.Value, elementPath []interface{}) bool) bool {
+
+	childValue = dereferenceList(childValue)
 
 	switch childValue.Kind() {
 	case reflect.Slice, reflect.Array:
+		elementType, nonNull := unwrapNonNull(listType.OfType)
 		l := childValue.Len()
 		this.data.WriteByte('[')
 		for i := 0; i < l; i++ {
 			if i > 0 {
 				this.data.WriteByte(',')
 			}
+			offset := this.data.Len()
 			element := childValue.Index(i)
-			switch elementType := listType.OfType.(type) {
-			case *schema.List:
-				this.writeList(*elementType, element, selectionResolver, writeElement)
-			default:
-				writeElement(elementType, element)
+			elementPath := appendPath(path, i)
+
+			ok := false
+			if isNull(element) {
+				if nonNull {
+					this.AddError(this.nilForNonNull(elementPath))
+				}
+			} else {
+				switch elementType := elementType.(type) {
+				case *schema.List:
+					ok = this.writeList(*elementType, element, elementPath, writeElement)
+				default:
+					ok = writeElement(elementType, element, elementPath)
+				}
+			}
+			if !ok {
+				if nonNull {
+					return false
+				}
+				this.data.Truncate(offset)
+				this.data.WriteString("null")
+				this.nulled = append(this.nulled, elementPath)
 			}
 		}
 		this.data.WriteByte(']')
+		return true
 	default:
 		i := childValue.Interface()
 		fmt.Println(i)
@@ -438,13 +683,15 @@ func (this *Execution) writeList(listType schema.List, childValue reflect.Value,
 	}
 }
 
-func (this *Execution) writeLeaf(childValue reflect.Value, selectionResolver *SelectionResolver, childType schema.Type) {
+// writeLeaf writes a scalar, enum or a (possibly nested) list of them.  It returns false if the value
+// is null because a non null list element is null.
+func (this *Execution) writeLeaf(childValue reflect.Value, selectionResolver *SelectionResolver, childType schema.Type) bool {
 	switch childType := childType.(type) {
 	case *schema.NonNull:
 		if childValue.Kind() == reflect.Ptr && childValue.Elem().IsNil() {
 			panic(qerrors.Errorf("got nil for non-null %q", childType))
 		} else {
-			this.writeLeaf(childValue, selectionResolver, childType.OfType)
+			return this.writeLeaf(childValue, selectionResolver, childType.OfType)
 		}
 
 	case *schema.Scalar:
@@ -466,13 +713,14 @@ func (this *Execution) writeLeaf(childValue reflect.Value, selectionResolver *Se
 		this.data.WriteByte('"')
 
 	case *schema.List:
-		this.writeList(*childType, childValue, selectionResolver, func(elementType schema.Type, element reflect.Value) {
-			this.writeLeaf(element, selectionResolver, childType.OfType)
+		return this.writeList(*childType, childValue, selectionResolver.path, func(elementType schema.Type, element reflect.Value, elementPath []interface{}) bool {
+			return this.writeLeaf(element, selectionResolver, elementType)
 		})
 
 	default:
 		panic(fmt.Sprintf("Unknown type: %s", childType))
 	}
+	return true
 }
 
 func (r *Execution) AddError(err error) {
@@ -498,3 +746,10 @@ func unwrapNonNull(t schema.Type) (schema.Type, bool) {
 	}
 	return t, false
 }
+
+// appendPath returns a copy of path with the element appended, so that paths never share backing arrays.
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	result := make([]interface{}, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, element)
+}
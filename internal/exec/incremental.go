@@ -0,0 +1,260 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/chirino/graphql/internal/exec/packer"
+	"github.com/chirino/graphql/internal/linkedmap"
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/schema"
+)
+
+// incrementalPart is a part of the result that was delayed by a @defer or @stream directive.
+type incrementalPart struct {
+	label string
+	path  []interface{}
+	// execute writes the part and returns false if its data is null.
+	execute func() bool
+}
+
+func (this *Execution) incrementalDelivery() bool {
+	return this.FireIncrementalEventFunc != nil && this.Operation.Type != schema.Subscription
+}
+
+// deferByDirective returns true if the fragment is annotated with an enabled @defer directive.
+func (this *Execution) deferByDirective(directives schema.DirectiveList) (label string, ok bool) {
+	if !this.incrementalDelivery() {
+		return "", false
+	}
+	d := directives.Get("defer")
+	if d == nil {
+		return "", false
+	}
+	enabled, err := this.evaluateDirectiveArg(d, "if", reflect.TypeOf(false), true)
+	if err != nil {
+		this.AddError(err)
+		return "", false
+	}
+	if !enabled.(bool) {
+		return "", false
+	}
+	return this.directiveLabel(d), true
+}
+
+// streamByDirective returns true if the field is annotated with an enabled @stream directive.
+func (this *Execution) streamByDirective(directives schema.DirectiveList) (label string, initialCount int, ok bool) {
+	if !this.incrementalDelivery() {
+		return "", 0, false
+	}
+	d := directives.Get("stream")
+	if d == nil {
+		return "", 0, false
+	}
+	enabled, err := this.evaluateDirectiveArg(d, "if", reflect.TypeOf(false), true)
+	if err != nil {
+		this.AddError(err)
+		return "", 0, false
+	}
+	if !enabled.(bool) {
+		return "", 0, false
+	}
+	count, err := this.evaluateDirectiveArg(d, "initialCount", reflect.TypeOf(int32(0)), int32(0))
+	if err != nil {
+		this.AddError(err)
+		return "", 0, false
+	}
+	initialCount = int(count.(int32))
+	if initialCount < 0 {
+		this.AddError(qerrors.New("initialCount must be a positive integer").WithLocations(d.NameLoc).WithStack())
+		return "", 0, false
+	}
+	return this.directiveLabel(d), initialCount, true
+}
+
+func (this *Execution) evaluateDirectiveArg(d *schema.Directive, name string, valueType reflect.Type, defaultValue interface{}) (interface{}, *qerrors.Error) {
+	arg, ok := d.Args.Get(name)
+	if !ok {
+		return defaultValue, nil
+	}
+	value := arg.Evaluate(this.Vars)
+	if value == nil {
+		return defaultValue, nil
+	}
+	p := packer.ValuePacker{ValueType: valueType}
+	v, err := p.Pack(value)
+	if err != nil {
+		return nil, qerrors.New(err.Error()).WithLocations(d.NameLoc).WithStack()
+	}
+	return v.Interface(), nil
+}
+
+func (this *Execution) directiveLabel(d *schema.Directive) string {
+	label, err := this.evaluateDirectiveArg(d, "label", reflect.TypeOf(""), "")
+	if err != nil {
+		this.AddError(err)
+		return ""
+	}
+	return label.(string)
+}
+
+// deferFragment delays the execution of a fragment until the initial payload has been delivered.
+func (this *Execution) deferFragment(ctx context.Context, objectPath []interface{}, label string, parentSelectionResolver *SelectionResolver, fragment *schema.Fragment, parentType schema.Type, parentValue reflect.Value) {
+	this.incremental = append(this.incremental, &incrementalPart{
+		label: label,
+		path:  objectPath,
+		execute: func() bool {
+			selectedFields := linkedmap.CreateLinkedMap(len(fragment.Selections))
+			this.CreateSelectionResolversForFragment(ctx, objectPath, parentSelectionResolver, fragment, parentType, parentValue, selectedFields)
+			return this.recursiveExecute(ctx, parentSelectionResolver, selectedFields)
+		},
+	})
+}
+
+// writeStream writes the first initialCount elements of a list and delays the
+// remaining elements until the initial payload has been delivered.  It returns false if the initial
+// elements make the list null.
+func (this *Execution) writeStream(ctx context.Context, selected *SelectionResolver, listType schema.List, childValue reflect.Value, label string, initialCount int) bool {
+	childValue = dereferenceList(childValue)
+	switch childValue.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		panic(fmt.Sprintf("Resolved object was not an array, it was a: %s", childValue.Type().String()))
+	}
+
+	l := childValue.Len()
+	if initialCount > l {
+		initialCount = l
+	}
+	initial := reflect.MakeSlice(reflect.SliceOf(childValue.Type().Elem()), initialCount, initialCount)
+	reflect.Copy(initial, childValue)
+	if !this.writeListItems(ctx, selected, listType, initial, selected.path) {
+		return false
+	}
+
+	for i := initialCount; i < l; i++ {
+		element := childValue.Index(i)
+		path := appendPath(selected.path, i)
+		this.incremental = append(this.incremental, &incrementalPart{
+			label: label,
+			path:  path,
+			execute: func() bool {
+				return this.writeListItem(ctx, selected, listType.OfType, element, path)
+			},
+		})
+	}
+	return true
+}
+
+func (this *Execution) writeListItems(ctx context.Context, selected *SelectionResolver, listType schema.List, childValue reflect.Value, path []interface{}) bool {
+	if selected.selections == nil {
+		return this.writeLeaf(childValue, selected, &listType)
+	}
+	return this.writeObjectList(ctx, selected, listType, childValue, path)
+}
+
+// writeListItem writes a streamed list element.  It returns false when the element is null but its
+// type does not allow it, the nulls of streamed elements can't propagate to the already delivered list.
+func (this *Execution) writeListItem(ctx context.Context, selected *SelectionResolver, elementType schema.Type, element reflect.Value, path []interface{}) bool {
+	nullableType, nonNull := unwrapNonNull(elementType)
+	if isNull(element) {
+		if nonNull {
+			this.AddError(this.nilForNonNull(path))
+			return false
+		}
+		this.data.WriteString("null")
+		return true
+	}
+	if selected.selections == nil {
+		return this.writeLeaf(element, selected, nullableType)
+	}
+	if listType, ok := nullableType.(*schema.List); ok {
+		return this.writeObjectList(ctx, selected, *listType, element, path)
+	}
+	selectedFields := linkedmap.CreateLinkedMap(len(selected.selections))
+	this.resolveFields(ctx, path, selected, selectedFields, element, nullableType, selected.selections)
+	return this.recursiveExecute(ctx, selected, selectedFields)
+}
+
+// executeIncremental executes the delayed parts and delivers each one as a subsequent payload.
+func (this *Execution) executeIncremental() {
+	for len(this.incremental) > 0 {
+		if this.Context.Err() != nil {
+			return
+		}
+		part := this.incremental[0]
+		this.incremental = this.incremental[1:]
+		this.executePart(part)
+		// executing the part may have delayed more parts, or nulled the paths of the queued ones.
+		this.dropNulledParts()
+		hasNext := len(this.incremental) > 0
+		this.FireIncrementalEventFunc(json.RawMessage(this.data.Bytes()), this.errs, part.path, part.label, hasNext)
+	}
+}
+
+// dropNulledParts removes the queued parts whose path is below a path that was nulled by an error,
+// since the client has no object to apply them to.
+func (this *Execution) dropNulledParts() {
+	if len(this.nulled) == 0 {
+		return
+	}
+	parts := this.incremental[:0]
+	for _, part := range this.incremental {
+		if !this.isNulled(part.path) {
+			parts = append(parts, part)
+		}
+	}
+	this.incremental = parts
+}
+
+func (this *Execution) isNulled(path []interface{}) bool {
+	for _, nulled := range this.nulled {
+		if hasPathPrefix(path, nulled) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPathPrefix(path []interface{}, prefix []interface{}) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, p := range prefix {
+		if path[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *Execution) executePart(part *incrementalPart) {
+	this.data = &bytes.Buffer{}
+	this.errs = qerrors.ErrorList{}
+	defer func() {
+		if value := recover(); value != nil {
+			this.Logger.LogPanic(this.Context, value)
+			err := makePanicError(value)
+			err.Path = pathStrings(part.path)
+			this.AddError(err)
+			this.data.Reset()
+			this.data.WriteString("null")
+		}
+	}()
+	if !part.execute() {
+		this.data.Reset()
+		this.data.WriteString("null")
+		this.nulled = append(this.nulled, part.path)
+	}
+}
+
+func pathStrings(path []interface{}) []string {
+	result := make([]string, len(path))
+	for i, p := range path {
+		result[i] = fmt.Sprint(p)
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"reflect"
+
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/resolvers"
+	"github.com/chirino/graphql/schema"
+)
+
+// checkOneOfArgs verifies that the @oneOf input objects passed to the arguments of a field, either
+// as literals or as variables, set exactly one of their fields to a non null value.
+func checkOneOfArgs(args schema.InputValueList, values map[string]interface{}) *qerrors.Error {
+	for _, arg := range args {
+		if err := checkOneOf(values[arg.Name], arg.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkOneOf(value interface{}, t schema.Type) *qerrors.Error {
+	if value == nil {
+		return nil
+	}
+	switch t := t.(type) {
+	case *schema.NonNull:
+		return checkOneOf(value, t.OfType)
+	case *schema.List:
+		if list, ok := value.([]interface{}); ok {
+			for _, element := range list {
+				if err := checkOneOf(element, t.OfType); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return checkOneOf(value, t.OfType)
+	case *schema.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if t.IsOneOf() {
+			if len(fields) != 1 {
+				return qerrors.Errorf("OneOf Input Object %q must specify exactly one key.", t.Name)
+			}
+			for name, field := range fields {
+				if field == nil {
+					return qerrors.Errorf("Field \"%s.%s\" must be non-null.", t.Name, name)
+				}
+			}
+		}
+		for _, f := range t.Fields {
+			if err := checkOneOf(fields[f.Name], f.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func errorResolution(err error) resolvers.Resolution {
+	return func() (reflect.Value, error) {
+		return reflect.Value{}, err
+	}
+}
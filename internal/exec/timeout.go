@@ -0,0 +1,82 @@
+package exec
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/resolvers"
+	"github.com/chirino/graphql/schema"
+)
+
+// fieldTimeout returns the timeout configured by the @timeout directive of a field definition.
+func fieldTimeout(field *schema.Field) time.Duration {
+	d := field.Directives.Get("timeout")
+	if d == nil {
+		return 0
+	}
+	arg, ok := d.Args.Get("ms")
+	if !ok {
+		return 0
+	}
+	ms, _ := arg.Evaluate(nil).(int32)
+	return time.Duration(ms) * time.Millisecond
+}
+
+// fieldContext applies the @timeout directive of the field to the context of its resolver.
+func (this *Execution) fieldContext(ctx context.Context, field *schema.Field) (context.Context, context.CancelFunc) {
+	timeout := fieldTimeout(field)
+	if timeout <= 0 {
+		return ctx, nil
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// withDeadline makes the resolution give up once its context is done, so that a slow resolver
+// does not hold up the sibling fields.  The resolver keeps running in the background until it
+// notices that its context is done.
+func (this *Execution) withDeadline(ctx context.Context, cancel context.CancelFunc, path func() []string, resolution resolvers.Resolution) resolvers.Resolution {
+	type result struct {
+		value reflect.Value
+		err   error
+	}
+	return func() (reflect.Value, error) {
+		if cancel != nil {
+			defer cancel()
+		}
+		if ctx.Err() != nil {
+			return reflect.Value{}, contextError(ctx)
+		}
+
+		done := make(chan result, 1)
+		go func() {
+			defer func() {
+				if value := recover(); value != nil {
+					this.Logger.LogPanic(this.Context, value)
+					err := makePanicError(value)
+					err.Path = path()
+					done <- result{err: err}
+				}
+			}()
+			value, err := resolution()
+			done <- result{value, err}
+		}()
+
+		select {
+		case r := <-done:
+			return r.value, r.err
+		case <-ctx.Done():
+			return reflect.Value{}, contextError(ctx)
+		}
+	}
+}
+
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return qerrors.New("field resolution timed out").WithExtensions(map[string]interface{}{
+			"code": "TIMEOUT",
+		})
+	}
+	return ctx.Err()
+}
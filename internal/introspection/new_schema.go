@@ -27,20 +27,23 @@ type typeRef struct {
 // skipping a field. Directives provide this by describing additional information
 // to the executor.
 type directive struct {
-	Name        string       `json:"name"`
-	Description *string      `json:"description"`
-	Locations   []string     `json:"locations"`
-	Args        []inputValue `json:"args"`
+	Name         string       `json:"name"`
+	Description  *string      `json:"description"`
+	Locations    []string     `json:"locations"`
+	Args         []inputValue `json:"args"`
+	IsRepeatable bool         `json:"isRepeatable"`
 }
 
 // Arguments provided to Fields or Directives and the input fields of an
 // InputObject are represented as Input Values which describe their type and
 // optionally a default value.
 type inputValue struct {
-	Name         string  `json:"name"`
-	Description  *string `json:"description"`
-	Type         typeRef `json:"type"`
-	DefaultValue *string `json:"defaultValue"`
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	Type              typeRef `json:"type"`
+	DefaultValue      *string `json:"defaultValue"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
 }
 
 // Object and Interface types are described by a list of Fields, each of which has
@@ -65,14 +68,16 @@ type enumValue struct {
 }
 
 type fullType struct {
-	Kind          *string      `json:"kind"`
-	Name          string       `json:"name"`
-	Description   *string      `json:"description"`
-	Fields        []field      `json:"fields"`
-	InputFields   []inputValue `json:"inputFields"`
-	Interfaces    []typeRef    `json:"interfaces"`
-	EnumValues    []enumValue  `json:"enumValues"`
-	PossibleTypes []typeRef    `json:"possibleTypes"`
+	Kind           *string      `json:"kind"`
+	Name           string       `json:"name"`
+	Description    *string      `json:"description"`
+	Fields         []field      `json:"fields"`
+	InputFields    []inputValue `json:"inputFields"`
+	Interfaces     []typeRef    `json:"interfaces"`
+	EnumValues     []enumValue  `json:"enumValues"`
+	PossibleTypes  []typeRef    `json:"possibleTypes"`
+	SpecifiedByURL *string      `json:"specifiedByURL"`
+	IsOneOf        *bool        `json:"isOneOf"`
 }
 
 // A GraphQL Schema defines the capabilities of a GraphQL server. It exposes all
@@ -90,7 +95,48 @@ type introspectionData struct {
 	Schema *schemaType `json:"__schema"`
 }
 
-const Query = `
+// QueryOptions select the fields, added to the introspection types by later versions of the spec,
+// that NewQuery asks for.  Servers that predate them reject a query that uses them.
+type QueryOptions struct {
+	// SpecifiedByURL queries the specifiedByURL of scalars.
+	SpecifiedByURL bool
+	// OneOf queries isOneOf of input objects.
+	OneOf bool
+	// DirectiveIsRepeatable queries isRepeatable of directives.
+	DirectiveIsRepeatable bool
+	// InputValueDeprecation queries the deprecated arguments and input fields.
+	InputValueDeprecation bool
+}
+
+// Query is the introspection query that servers of all versions accept.
+var Query = NewQuery(QueryOptions{})
+
+// NewQuery returns the introspection query that NewSchema converts the result of.
+func NewQuery(options QueryOptions) string {
+	includeDeprecated := ""
+	inputValueDeprecation := ""
+	if options.InputValueDeprecation {
+		includeDeprecated = "(includeDeprecated: true)"
+		inputValueDeprecation = `
+   isDeprecated
+   deprecationReason`
+	}
+	isRepeatable := ""
+	if options.DirectiveIsRepeatable {
+		isRepeatable = `
+       isRepeatable`
+	}
+	specifiedByURL := ""
+	if options.SpecifiedByURL {
+		specifiedByURL = `
+   specifiedByURL`
+	}
+	isOneOf := ""
+	if options.OneOf {
+		isOneOf = `
+   isOneOf`
+	}
+	return `
  query IntrospectionQuery {
    __schema {
      queryType { name }
@@ -103,9 +149,9 @@ const Query = `
        name
        description
        locations
-       args {
+       args` + includeDeprecated + ` {
          ...InputValue
-       }
+       }` + isRepeatable + `
      }
    }
  }
@@ -116,7 +162,7 @@ const Query = `
    fields(includeDeprecated: true) {
      name
      description
-     args {
+     args` + includeDeprecated + ` {
        ...InputValue
      }
      type {
@@ -125,7 +171,7 @@ const Query = `
      isDeprecated
      deprecationReason
    }
-   inputFields {
+   inputFields` + includeDeprecated + ` {
      ...InputValue
    }
    interfaces {
@@ -139,13 +185,13 @@ const Query = `
    }
    possibleTypes {
      ...TypeRef
-   }
+   }` + specifiedByURL + isOneOf + `
  }
  fragment InputValue on __InputValue {
    name
    description
    type { ...TypeRef }
-   defaultValue
+   defaultValue` + inputValueDeprecation + `
  }
  fragment TypeRef on __Type {
    kind
@@ -180,6 +226,7 @@ const Query = `
    }
  }
 `
+}
 
 func NewSchema(introspection json.RawMessage) (*schema.Schema, error) {
 
@@ -197,9 +244,11 @@ func NewSchema(introspection json.RawMessage) (*schema.Schema, error) {
 		}
 
 		s.DeclaredDirectives[d.Name] = &schema.DirectiveDecl{
-			Desc: desc(d.Description),
-			Name: d.Name,
-			Args: args(d.Args),
+			Desc:       desc(d.Description),
+			Name:       d.Name,
+			Locs:       d.Locations,
+			Args:       args(d.Args),
+			Repeatable: d.IsRepeatable,
 		}
 	}
 	for _, t := range data.Schema.Types {
@@ -220,14 +269,16 @@ func NewSchema(introspection json.RawMessage) (*schema.Schema, error) {
 			}
 		case "INTERFACE":
 			s.Types[t.Name] = &schema.Interface{
-				Desc:   desc(t.Description),
-				Name:   t.Name,
-				Fields: toFields(t.Fields),
+				Desc:           desc(t.Description),
+				Name:           t.Name,
+				InterfaceNames: toSimpleNames(t.Interfaces),
+				Fields:         toFields(t.Fields),
 			}
 		case "SCALAR":
 			s.Types[t.Name] = &schema.Scalar{
-				Desc: desc(t.Description),
-				Name: t.Name,
+				Desc:       desc(t.Description),
+				Name:       t.Name,
+				Directives: specifiedBy(t.SpecifiedByURL),
 			}
 		case "UNION":
 			s.Types[t.Name] = &schema.Union{
@@ -243,9 +294,10 @@ func NewSchema(introspection json.RawMessage) (*schema.Schema, error) {
 			}
 		case "INPUT_OBJECT":
 			s.Types[t.Name] = &schema.InputObject{
-				Desc:   desc(t.Description),
-				Name:   t.Name,
-				Fields: toInputFields(t.InputFields),
+				Desc:       desc(t.Description),
+				Name:       t.Name,
+				Fields:     toInputFields(t.InputFields),
+				Directives: oneOf(t.IsOneOf),
 			}
 		default:
 			return nil, fmt.Errorf("invalid kind: %s", *t.Kind)
@@ -281,8 +333,31 @@ func toEnumValues(values []enumValue) []*schema.EnumValue {
 }
 
 func directives(deprecated bool, reason *string) schema.DirectiveList {
-	// TODO: implement me...
-	return nil
+	if !deprecated {
+		return nil
+	}
+	d := &schema.Directive{Name: "deprecated"}
+	if reason != nil {
+		d.Args = schema.ArgumentList{{Name: "reason", Value: schema.ToLiteral(*reason)}}
+	}
+	return schema.DirectiveList{d}
+}
+
+func specifiedBy(url *string) schema.DirectiveList {
+	if url == nil {
+		return nil
+	}
+	return schema.DirectiveList{&schema.Directive{
+		Name: "specifiedBy",
+		Args: schema.ArgumentList{{Name: "url", Value: schema.ToLiteral(*url)}},
+	}}
+}
+
+func oneOf(isOneOf *bool) schema.DirectiveList {
+	if isOneOf == nil || !*isOneOf {
+		return nil
+	}
+	return schema.DirectiveList{&schema.Directive{Name: "oneOf"}}
 }
 
 func toSimpleNames(interfaces []typeRef) []string {
@@ -312,9 +387,10 @@ func toInputFields(args []inputValue) schema.InputValueList {
 	rc := schema.InputValueList{}
 	for _, arg := range args {
 		rc = append(rc, &schema.InputValue{
-			Desc: desc(arg.Description),
-			Name: arg.Name,
-			Type: toType(arg.Type),
+			Desc:       desc(arg.Description),
+			Name:       arg.Name,
+			Type:       toType(arg.Type),
+			Directives: directives(arg.IsDeprecated, arg.DeprecationReason),
 		})
 	}
 	return rc
@@ -336,10 +412,11 @@ func args(args []inputValue) schema.InputValueList {
 	rc := schema.InputValueList{}
 	for _, arg := range args {
 		rc = append(rc, &schema.InputValue{
-			Desc:    desc(arg.Description),
-			Name:    arg.Name,
-			Type:    toType(arg.Type),
-			Default: toLiteral(arg.Type, arg.DefaultValue),
+			Desc:       desc(arg.Description),
+			Name:       arg.Name,
+			Type:       toType(arg.Type),
+			Default:    toLiteral(arg.Type, arg.DefaultValue),
+			Directives: directives(arg.IsDeprecated, arg.DeprecationReason),
 		})
 	}
 	return rc
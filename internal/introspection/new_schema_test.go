@@ -16,3 +16,48 @@ func TestGetSchema(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, engine.Schema.String(), s.String())
 }
+
+func TestGetSchemaWithSpecifiedByOneOfAndDeprecatedInputValues(t *testing.T) {
+	engine := graphql.New()
+	err := engine.Schema.Parse(`
+		schema {
+			query: Query
+		}
+		scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+		input PetBy @oneOf {
+			id: UUID
+			name: String @deprecated(reason: "Use id")
+		}
+		type Query {
+			pet(by: PetBy, limit: Int @deprecated): String
+		}
+	`)
+	require.NoError(t, err)
+	s, err := graphql.GetSchema(engine.ServeGraphQL, graphql.IntrospectionOptions{
+		SpecifiedByURL:        true,
+		OneOf:                 true,
+		DirectiveIsRepeatable: true,
+		InputValueDeprecation: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, engine.Schema.String(), s.String())
+}
+
+func TestGetSchemaQueriesOnlyTheBaselineFieldsByDefault(t *testing.T) {
+	engine := graphql.New()
+	engine.Schema.Parse(starwars.Schema)
+	query := ""
+	_, err := graphql.GetSchema(func(request *graphql.Request) *graphql.Response {
+		query = request.Query
+		return engine.ServeGraphQL(request)
+	})
+	require.NoError(t, err)
+
+	// servers that predate the newer introspection fields reject a query that uses them.
+	assert.NotContains(t, query, "specifiedByURL")
+	assert.NotContains(t, query, "isOneOf")
+	assert.NotContains(t, query, "isRepeatable")
+	assert.NotContains(t, query, "args(includeDeprecated: true)")
+	assert.NotContains(t, query, "inputFields(includeDeprecated: true)")
+	assert.Contains(t, query, "defaultValue\n }")
+}
@@ -125,13 +125,18 @@ func (r *Type) Fields(args *struct{ IncludeDeprecated bool }) *[]*Field {
 }
 
 func (r *Type) Interfaces() *[]*Type {
-	t, ok := r.typ.(*schema.Object)
-	if !ok {
+	var interfaces schema.InterfaceList
+	switch t := r.typ.(type) {
+	case *schema.Object:
+		interfaces = t.Interfaces
+	case *schema.Interface:
+		interfaces = t.Interfaces
+	default:
 		return nil
 	}
 
-	l := make([]*Type, len(t.Interfaces))
-	for i, intf := range t.Interfaces {
+	l := make([]*Type, len(interfaces))
+	for i, intf := range interfaces {
 		l[i] = &Type{intf}
 	}
 	return &l
@@ -174,16 +179,13 @@ func (r *Type) EnumValues(args *struct{ IncludeDeprecated bool }) *[]*EnumValue
 	return &l
 }
 
-func (r *Type) InputFields() *[]*InputValue {
+func (r *Type) InputFields(args *struct{ IncludeDeprecated bool }) *[]*InputValue {
 	t, ok := r.typ.(*schema.InputObject)
 	if !ok {
 		return nil
 	}
 
-	l := make([]*InputValue, len(t.Fields))
-	for i, v := range t.Fields {
-		l[i] = &InputValue{v}
-	}
+	l := inputValues(t.Fields, args.IncludeDeprecated)
 	return &l
 }
 
@@ -198,6 +200,28 @@ func (r *Type) OfType() *Type {
 	}
 }
 
+func (r *Type) SpecifiedByURL() *string {
+	t, ok := r.typ.(*schema.Scalar)
+	if !ok {
+		return nil
+	}
+	d := t.Directives.Get("specifiedBy")
+	if d == nil {
+		return nil
+	}
+	url := d.Args.MustGet("url").Evaluate(nil).(string)
+	return &url
+}
+
+func (r *Type) IsOneOf() *bool {
+	t, ok := r.typ.(*schema.InputObject)
+	if !ok {
+		return nil
+	}
+	oneOf := t.IsOneOf()
+	return &oneOf
+}
+
 type Field struct {
 	field *schema.Field
 }
@@ -222,12 +246,8 @@ func Description(desc schema.Description) *string {
 	return &desc.Text
 }
 
-func (r *Field) Args() []*InputValue {
-	l := make([]*InputValue, len(r.field.Args))
-	for i, v := range r.field.Args {
-		l[i] = &InputValue{v}
-	}
-	return l
+func (r *Field) Args(args *struct{ IncludeDeprecated bool }) []*InputValue {
+	return inputValues(r.field.Args, args.IncludeDeprecated)
 }
 
 func (r *Field) Type() *Type {
@@ -275,6 +295,29 @@ func (r *InputValue) DefaultValue() *string {
 	return &s
 }
 
+func (r *InputValue) IsDeprecated() bool {
+	return r.value.Directives.Get("deprecated") != nil
+}
+
+func (r *InputValue) DeprecationReason() *string {
+	d := r.value.Directives.Get("deprecated")
+	if d == nil {
+		return nil
+	}
+	reason := d.Args.MustGet("reason").Evaluate(nil).(string)
+	return &reason
+}
+
+func inputValues(values schema.InputValueList, includeDeprecated bool) []*InputValue {
+	l := []*InputValue{}
+	for _, v := range values {
+		if d := v.Directives.Get("deprecated"); d == nil || includeDeprecated {
+			l = append(l, &InputValue{v})
+		}
+	}
+	return l
+}
+
 type EnumValue struct {
 	value *schema.EnumValue
 }
@@ -316,10 +359,10 @@ func (r *Directive) Locations() []string {
 	return r.directive.Locs
 }
 
-func (r *Directive) Args() []*InputValue {
-	l := make([]*InputValue, len(r.directive.Args))
-	for i, v := range r.directive.Args {
-		l[i] = &InputValue{v}
-	}
-	return l
+func (r *Directive) IsRepeatable() bool {
+	return r.directive.Repeatable
+}
+
+func (r *Directive) Args(args *struct{ IncludeDeprecated bool }) []*InputValue {
+	return inputValues(r.directive.Args, args.IncludeDeprecated)
 }
@@ -0,0 +1,85 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a size bounded, concurrency safe, least recently used cache.
+type Cache struct {
+	size    int
+	mu      sync.Mutex
+	entries *list.List
+	index   map[interface{}]*list.Element
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+}
+
+// New creates a Cache that holds up to size entries.  A size <= 0 creates
+// a Cache that never holds any entries.
+func New(size int) *Cache {
+	return &Cache{
+		size:    size,
+		entries: list.New(),
+		index:   map[interface{}]*list.Element{},
+	}
+}
+
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.index[key]; ok {
+		c.entries.MoveToFront(e)
+		return e.Value.(*entry).value, true
+	}
+	return nil, false
+}
+
+// Add stores the value under the key and returns the entry that was evicted
+// to make room for it, if any.
+func (c *Cache) Add(key interface{}, value interface{}) (evicted interface{}, ok bool) {
+	if c.size <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, found := c.index[key]; found {
+		c.entries.MoveToFront(e)
+		e.Value.(*entry).value = value
+		return nil, false
+	}
+	c.index[key] = c.entries.PushFront(&entry{key: key, value: value})
+	if c.entries.Len() > c.size {
+		last := c.entries.Back()
+		c.entries.Remove(last)
+		delete(c.index, last.Value.(*entry).key)
+		return last.Value.(*entry).value, true
+	}
+	return nil, false
+}
+
+func (c *Cache) Remove(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.index[key]; ok {
+		c.entries.Remove(e)
+		delete(c.index, key)
+	}
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// Purge removes all the entries from the cache.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Init()
+	c.index = map[interface{}]*list.Element{}
+}
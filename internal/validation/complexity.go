@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/chirino/graphql/exec"
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/schema"
+)
+
+// listSizeArguments are the arguments used as multipliers of list fields that don't
+// configure multipliers with a @cost directive.
+var listSizeArguments = []string{"first", "last", "limit"}
+
+// Complexity computes the cost of executing an operation.  Every selected field costs the weight
+// configured by the `@cost(weight: Int, multipliers: [String!])` directive on the field definition
+// (1 by default) plus the cost of its sub selections.  That cost is then multiplied by the value of the
+// multipliers arguments, which default to the `first`, `last` or `limit` arguments of list fields.
+func Complexity(s *schema.Schema, doc *schema.QueryDocument, op *schema.Operation, vars map[string]interface{}) int {
+	c := &complexityContext{
+		schema:    s,
+		doc:       doc,
+		vars:      withDefaultValues(op, vars),
+		fragments: map[string]bool{},
+	}
+	return c.selectionsCost(op.Selections, s.EntryPoints[op.Type])
+}
+
+// ValidateComplexity returns an error if the complexity of the operation exceeds maxComplexity.
+func ValidateComplexity(s *schema.Schema, doc *schema.QueryDocument, op *schema.Operation, vars map[string]interface{}, maxComplexity int) (int, *qerrors.Error) {
+	complexity := Complexity(s, doc, op, vars)
+	if maxComplexity > 0 && complexity > maxComplexity {
+		return complexity, (&qerrors.Error{
+			Message:   fmt.Sprintf("Operation has complexity %d that exceeds max complexity %d", complexity, maxComplexity),
+			Locations: []qerrors.Location{op.Loc},
+			Rule:      "MaxComplexityExceeded",
+		}).WithStack()
+	}
+	return complexity, nil
+}
+
+type complexityContext struct {
+	schema    *schema.Schema
+	doc       *schema.QueryDocument
+	vars      map[string]interface{}
+	fragments map[string]bool
+}
+
+func (c *complexityContext) selectionsCost(sels []schema.Selection, t schema.Type) int {
+	cost := 0
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *schema.FieldSelection:
+			if c.skip(sel.Directives) {
+				continue
+			}
+			cost = addCost(cost, c.fieldCost(sel, t))
+		case *schema.InlineFragment:
+			if c.skip(sel.Directives) {
+				continue
+			}
+			onType := t
+			if sel.On.Name != "" {
+				onType = c.schema.Types[sel.On.Name]
+			}
+			cost = addCost(cost, c.selectionsCost(sel.Selections, onType))
+		case *schema.FragmentSpread:
+			if c.skip(sel.Directives) {
+				continue
+			}
+			frag := c.doc.Fragments.Get(sel.Name)
+			// guard against fragment cycles in case validation is disabled.
+			if frag == nil || c.fragments[sel.Name] {
+				continue
+			}
+			c.fragments[sel.Name] = true
+			cost = addCost(cost, c.selectionsCost(frag.Selections, c.schema.Types[frag.On.Name]))
+			delete(c.fragments, sel.Name)
+		}
+	}
+	return cost
+}
+
+func (c *complexityContext) fieldCost(sel *schema.FieldSelection, t schema.Type) int {
+	f := fields(t).Get(sel.Name)
+	if f == nil {
+		// meta fields like __typename
+		return 0
+	}
+
+	weight := 1
+	var multipliers []string
+	if d := f.Directives.Get("cost"); d != nil {
+		// the arguments that are not set were given their default value when the schema was resolved.
+		if w, ok := toInt(argumentValue(d.Args, "weight", nil)); ok {
+			weight = w
+		}
+		if list, ok := argumentValue(d.Args, "multipliers", nil).([]interface{}); ok {
+			for _, m := range list {
+				if m, ok := m.(string); ok {
+					multipliers = append(multipliers, m)
+				}
+			}
+		}
+	} else if isList(f.Type) {
+		multipliers = listSizeArguments
+	}
+
+	cost := addCost(weight, c.selectionsCost(sel.Selections, unwrapType(f.Type)))
+	for _, name := range multipliers {
+		if m, ok := toInt(argumentValue(sel.Arguments, name, c.vars)); ok && m > 0 {
+			cost = mulCost(cost, m)
+		}
+	}
+	return cost
+}
+
+// argumentValue returns the value of the named argument, or nil if it's not set.
+func argumentValue(args schema.ArgumentList, name string, vars map[string]interface{}) interface{} {
+	value, ok := args.Get(name)
+	if !ok || value == nil {
+		return nil
+	}
+	return value.Evaluate(vars)
+}
+
+// withDefaultValues returns the variables of the request with the default values of the operation
+// variables that are not set.
+func withDefaultValues(op *schema.Operation, vars map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(vars))
+	for name, value := range vars {
+		result[name] = value
+	}
+	for _, v := range op.Vars {
+		name := strings.TrimPrefix(v.Name, "$")
+		if _, ok := result[name]; !ok && v.Default != nil {
+			result[name] = v.Default.Evaluate(nil)
+		}
+	}
+	return result
+}
+
+func (c *complexityContext) skip(directives schema.DirectiveList) bool {
+	skip, err := exec.SkipByDirective(directives, c.vars)
+	return err == nil && skip
+}
+
+func isList(t schema.Type) bool {
+	if nn, ok := t.(*schema.NonNull); ok {
+		t = nn.OfType
+	}
+	_, ok := t.(*schema.List)
+	return ok
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+func addCost(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func mulCost(a, b int) int {
+	if a != 0 && b > math.MaxInt32/a {
+		return math.MaxInt32
+	}
+	return a * b
+}
@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/chirino/graphql/schema"
+)
+
+const complexitySchema = `
+	schema {
+		query: Query
+	}
+
+	type Query {
+		characters(first: Int): [Character]!
+		search(text: String, count: Int): [Character] @cost(weight: 10, multipliers: ["count"])
+		hero: Character @cost(weight: 5)
+		droids(count: Int): [Character]! @cost(multipliers: ["count"])
+	}
+
+	type Character {
+		id: ID!
+		name: String!
+		friends(limit: Int): [Character]!
+	}`
+
+type complexityTestCase struct {
+	name       string
+	query      string
+	vars       map[string]interface{}
+	complexity int
+}
+
+func TestComplexity(t *testing.T) {
+	s := schema.New()
+	err := s.Parse(complexitySchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []complexityTestCase{
+		{
+			name:       "fields",
+			query:      `{ characters { id name } }`,
+			complexity: 3,
+		},
+		{
+			name:       "list size arguments",
+			query:      `{ characters(first: 10) { id friends(limit: 5) { name } } }`,
+			complexity: (1 + 1 + (1+1)*5) * 10,
+		},
+		{
+			name:       "variables",
+			query:      `query ($n: Int) { characters(first: $n) { id } }`,
+			vars:       map[string]interface{}{"n": float64(100)},
+			complexity: (1 + 1) * 100,
+		},
+		{
+			name:       "cost directive",
+			query:      `{ search(text: "luke", count: 3) { name } }`,
+			complexity: (10 + 1) * 3,
+		},
+		{
+			name:       "cost directive without multipliers",
+			query:      `{ hero { name } }`,
+			complexity: 5 + 1,
+		},
+		{
+			name:       "cost directive default weight",
+			query:      `{ droids(count: 4) { id } }`,
+			complexity: (1 + 1) * 4,
+		},
+		{
+			name:       "variable default values",
+			query:      `query ($n: Int = 20) { droids(count: $n) { id } }`,
+			complexity: (1 + 1) * 20,
+		},
+		{
+			name:       "variables override default values",
+			query:      `query ($n: Int = 20) { droids(count: $n) { id } }`,
+			vars:       map[string]interface{}{"n": float64(3)},
+			complexity: (1 + 1) * 3,
+		},
+		{
+			name:       "fragments and skipped fields",
+			query:      `{ characters { ...F  name @skip(if: true) } } fragment F on Character { id name }`,
+			complexity: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := &schema.QueryDocument{}
+			if err := doc.Parse(tc.query); err != nil {
+				t.Fatal(err)
+			}
+			if errs := Validate(s, doc, 0); len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			complexity := Complexity(s, doc, doc.Operations[0], tc.vars)
+			if complexity != tc.complexity {
+				t.Errorf("expected complexity %d, actual %d", tc.complexity, complexity)
+			}
+
+			_, qErr := ValidateComplexity(s, doc, doc.Operations[0], tc.vars, tc.complexity-1)
+			if qErr == nil || qErr.Rule != "MaxComplexityExceeded" {
+				t.Errorf("expected a MaxComplexityExceeded error, got: %v", qErr)
+			}
+		})
+	}
+}
@@ -578,13 +578,15 @@ func validateDirectives(c *opContext, loc string, directives schema.DirectiveLis
 	directiveNames := make(nameSet)
 	for _, d := range directives {
 		dirName := d.Name
-		validateNameCustomMsg(c.context, directiveNames, d.Name, d.NameLoc, "UniqueDirectivesPerLocation", func() string {
-			return fmt.Sprintf("The directive %q can only be used once at this location.", dirName)
-		})
+		dd, ok := c.schema.DeclaredDirectives[dirName]
+		if !ok || !dd.Repeatable {
+			validateNameCustomMsg(c.context, directiveNames, d.Name, d.NameLoc, "UniqueDirectivesPerLocation", func() string {
+				return fmt.Sprintf("The directive %q can only be used once at this location.", dirName)
+			})
+		}
 
 		validateArgumentLiterals(c, d.Args)
 
-		dd, ok := c.schema.DeclaredDirectives[dirName]
 		if !ok {
 			c.addErr(d.NameLoc, "KnownDirectives", "Unknown directive %q.", dirName)
 			continue
@@ -746,6 +748,11 @@ func validateValueType(c *opContext, v schema.Literal, t schema.Type) (bool, str
 				return false, fmt.Sprintf("In field %q: %s", name, reason)
 			}
 		}
+		if t.IsOneOf() {
+			if ok, reason := validateOneOf(c, v, t); !ok {
+				return false, reason
+			}
+		}
 		for _, iv := range t.Fields {
 			found := false
 			for _, f := range v.Fields {
@@ -766,6 +773,28 @@ func validateValueType(c *opContext, v schema.Literal, t schema.Type) (bool, str
 	return false, fmt.Sprintf("Expected type %q, found %s.", t, v)
 }
 
+// validateOneOf checks that a @oneOf input object sets exactly one field to a non null value, a variable
+// used for that field must be defined as non nullable by each operation that uses it.
+func validateOneOf(c *opContext, v *schema.ObjectLit, t *schema.InputObject) (bool, string) {
+	if len(v.Fields) != 1 {
+		return false, fmt.Sprintf("OneOf Input Object %q must specify exactly one key.", t)
+	}
+	f := v.Fields[0]
+	if isNull(f.Value) {
+		return false, fmt.Sprintf("Field \"%s.%s\" must be non-null.", t, f.Name)
+	}
+	if variable, ok := f.Value.(*schema.Variable); ok {
+		for _, op := range c.ops {
+			if v2 := op.Vars.Get(variable.String()); v2 != nil {
+				if _, ok := v2.Type.(*schema.NonNull); !ok {
+					c.addErrMultiLoc([]qerrors.Location{v2.Loc, variable.Loc}, "VariablesInAllowedPosition", "Variable %q of type %q must be non-nullable to be used for OneOf Input Object %q.", variable.String(), v2.Type, t)
+				}
+			}
+		}
+	}
+	return true, ""
+}
+
 func validateBasicLit(v *schema.BasicLit, t schema.Type) bool {
 	switch t := t.(type) {
 	case *schema.Scalar:
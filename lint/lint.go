@@ -0,0 +1,155 @@
+// Package lint checks schemas against a set of rules, like naming conventions or required descriptions, that
+// the schema parser does not enforce.
+package lint
+
+import (
+	"sort"
+
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/schema"
+)
+
+// Rule checks a schema and reports the problems it finds.  Implement it to add your own rules to a Linter.
+type Rule interface {
+	// Name identifies the rule, it is set as the Rule of the errors the rule reports.
+	Name() string
+	// Check returns the problems found in the schema.
+	Check(s *schema.Schema) qerrors.ErrorList
+}
+
+type ruleFunc struct {
+	name  string
+	check func(s *schema.Schema) qerrors.ErrorList
+}
+
+func (r *ruleFunc) Name() string                             { return r.name }
+func (r *ruleFunc) Check(s *schema.Schema) qerrors.ErrorList { return r.check(s) }
+
+// NewRule creates a Rule from a check function.
+func NewRule(name string, check func(s *schema.Schema) qerrors.ErrorList) Rule {
+	return &ruleFunc{name: name, check: check}
+}
+
+// Linter checks schemas against its Rules.
+type Linter struct {
+	Rules []Rule
+}
+
+// New creates a Linter for the given rules, use DefaultRules() to start from the built in ones.
+func New(rules ...Rule) *Linter {
+	return &Linter{Rules: rules}
+}
+
+// DefaultRules returns the built in rules with their default configuration.
+func DefaultRules() []Rule {
+	return []Rule{
+		&NamingConvention{},
+		&RequiredDescriptions{Types: true, Fields: true},
+		&RelayConnections{},
+		&UnusedTypes{},
+		&InputOutputNames{},
+		&DeprecationReasons{},
+	}
+}
+
+// Lint checks a schema against the default rules.
+func Lint(s *schema.Schema) qerrors.ErrorList {
+	return New(DefaultRules()...).Lint(s)
+}
+
+// Lint checks the schema against all the rules of the linter and returns the problems found ordered by
+// their location.
+func (l *Linter) Lint(s *schema.Schema) qerrors.ErrorList {
+	errs := qerrors.ErrorList{}
+	for _, rule := range l.Rules {
+		for _, err := range rule.Check(s) {
+			if err.Rule == "" {
+				err.Rule = rule.Name()
+			}
+			errs = append(errs, err)
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return location(errs[i]).Before(location(errs[j]))
+	})
+	return errs
+}
+
+func location(err *qerrors.Error) qerrors.Location {
+	if len(err.Locations) == 0 {
+		return qerrors.Location{}
+	}
+	return err.Locations[0]
+}
+
+// errorf creates an error at the location of a schema element, schemas that were not parsed from a
+// document have no locations.
+func errorf(loc qerrors.Location, format string, a ...interface{}) *qerrors.Error {
+	err := qerrors.Errorf(format, a...).ClearStack()
+	if loc.Line > 0 {
+		err = err.WithLocations(loc)
+	}
+	return err
+}
+
+// namedTypes returns the types defined by the schema, without the built in ones, ordered by name.
+func namedTypes(s *schema.Schema) []schema.NamedType {
+	var names []string
+	for name := range s.Types {
+		if schema.Meta.Types[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	types := make([]schema.NamedType, len(names))
+	for i, name := range names {
+		types[i] = s.Types[name]
+	}
+	return types
+}
+
+// directives returns the directives declared by the schema, without the built in ones, ordered by name.
+func directives(s *schema.Schema) []*schema.DirectiveDecl {
+	var names []string
+	for name := range s.DeclaredDirectives {
+		if schema.Meta.DeclaredDirectives[name] == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	decls := make([]*schema.DirectiveDecl, len(names))
+	for i, name := range names {
+		decls[i] = s.DeclaredDirectives[name]
+	}
+	return decls
+}
+
+// fields returns the fields of object and interface types.
+func fields(t schema.NamedType) schema.FieldList {
+	switch t := t.(type) {
+	case *schema.Object:
+		return t.Fields
+	case *schema.Interface:
+		return t.Fields
+	}
+	return nil
+}
+
+// typeLoc returns the location of the definition of a type.
+func typeLoc(t schema.NamedType) qerrors.Location {
+	switch t := t.(type) {
+	case *schema.Scalar:
+		return t.Loc
+	case *schema.Object:
+		return t.Loc
+	case *schema.Interface:
+		return t.Loc
+	case *schema.Union:
+		return t.Loc
+	case *schema.Enum:
+		return t.Loc
+	case *schema.InputObject:
+		return t.Loc
+	}
+	return qerrors.Location{}
+}
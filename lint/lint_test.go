@@ -0,0 +1,128 @@
+package lint_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/chirino/graphql/lint"
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, sdl string) *schema.Schema {
+	s := schema.New()
+	require.NoError(t, s.Parse(sdl))
+	return s
+}
+
+func messages(errs qerrors.ErrorList) []string {
+	r := []string{}
+	for _, err := range errs {
+		r = append(r, err.Rule+": "+err.Error())
+	}
+	return r
+}
+
+func TestLint(t *testing.T) {
+	s := mustParse(t, `
+"The queries"
+schema {
+  query: Query
+}
+"The queries"
+type Query {
+  "Lists the pets"
+  pets(first: Int, after: String): PetConnection!
+  "Finds a pet"
+  find_pet(filter: PetFilter): Pet @deprecated
+}
+"A page of pets"
+type PetConnection {
+  "The pets"
+  edges: [PetEdge]
+}
+"A pet and its cursor"
+type PetEdge {
+  "The pet"
+  node: Pet
+}
+"A pet"
+type Pet {
+  "The name"
+  name: String
+  "The kind"
+  kind: kind
+}
+enum kind { Cat DOG }
+"Filters pets"
+input PetFilter {
+  "The name"
+  name: String
+}
+"Never used"
+type Orphan {
+  "The id"
+  id: ID
+}
+`)
+	assert.Equal(t, []string{
+		`NamingConvention: graphql: Field "Query.find_pet" does not match the naming convention ^[a-z][a-zA-Z0-9]*$ (line 11, column 3)`,
+		`DeprecationReasons: graphql: "Query.find_pet" is deprecated without a reason (line 11, column 36)`,
+		`RelayConnections: graphql: Connection "PetConnection" must have a "pageInfo: PageInfo!" field (line 14, column 6)`,
+		`RelayConnections: graphql: Edge "PetEdge" must have a "cursor" field (line 19, column 6)`,
+		`NamingConvention: graphql: Type "kind" does not match the naming convention ^[A-Z][a-zA-Z0-9]*$ (line 30, column 6)`,
+		`RequiredDescriptions: graphql: Type "kind" has no description (line 30, column 6)`,
+		`NamingConvention: graphql: Enum value "kind.Cat" does not match the naming convention ^[A-Z][A-Z0-9_]*$ (line 30, column 13)`,
+		`InputOutputNames: graphql: Input type "PetFilter" must be named with the "Input" suffix (line 32, column 7)`,
+		`UnusedTypes: graphql: Type "Orphan" is not used (line 37, column 6)`,
+	}, messages(lint.Lint(s)))
+}
+
+func TestLintConfiguration(t *testing.T) {
+	s := mustParse(t, `
+schema {
+  query: Query
+}
+type Query {
+  pet(filter: PetFilter): PetFilter
+}
+input PetFilter {
+  name: String
+}
+`)
+	noUnderscores := lint.NewRule("NoUnderscores", func(s *schema.Schema) qerrors.ErrorList {
+		errs := qerrors.ErrorList{}
+		for _, f := range s.Types["Query"].(*schema.Object).Fields {
+			if regexp.MustCompile(`_`).MatchString(f.Name) {
+				errs = append(errs, qerrors.Errorf("Field %q has an underscore", f.Name))
+			}
+		}
+		return errs
+	})
+	linter := lint.New(
+		&lint.InputOutputNames{InputSuffix: "Filter"},
+		&lint.NamingConvention{FieldNames: regexp.MustCompile(`^[a-z]{4,}$`)},
+		&lint.RequiredDescriptions{Arguments: true},
+		noUnderscores,
+	)
+	assert.Equal(t, []string{
+		`NamingConvention: graphql: Field "Query.pet" does not match the naming convention ^[a-z]{4,}$ (line 6, column 3)`,
+		`RequiredDescriptions: graphql: Argument "Query.pet(filter:)" has no description (line 6, column 7)`,
+	}, messages(linter.Lint(s)))
+
+	s = mustParse(t, `
+schema {
+  query: Query
+}
+type Query {
+  pet_name: String
+}
+`)
+	// errors without a location come first.
+	assert.Equal(t, []string{
+		`NoUnderscores: graphql: Field "pet_name" has an underscore`,
+		`NamingConvention: graphql: Field "Query.pet_name" does not match the naming convention ^[a-z]{4,}$ (line 6, column 3)`,
+	}, messages(linter.Lint(s)))
+}
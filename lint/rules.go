@@ -0,0 +1,364 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/chirino/graphql/qerrors"
+	"github.com/chirino/graphql/schema"
+)
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	camelCase  = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	upperCase  = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+func orDefault(r *regexp.Regexp, def *regexp.Regexp) *regexp.Regexp {
+	if r == nil {
+		return def
+	}
+	return r
+}
+
+// NamingConvention checks the names of types, fields, arguments, enum values and directives against regular
+// expressions.  The expressions that are not set default to PascalCase for types, camelCase for fields,
+// arguments and directives, and UPPER_CASE for enum values.
+type NamingConvention struct {
+	TypeNames      *regexp.Regexp
+	FieldNames     *regexp.Regexp
+	ArgumentNames  *regexp.Regexp
+	EnumValueNames *regexp.Regexp
+	DirectiveNames *regexp.Regexp
+}
+
+func (r *NamingConvention) Name() string { return "NamingConvention" }
+
+func (r *NamingConvention) Check(s *schema.Schema) qerrors.ErrorList {
+	typeNames := orDefault(r.TypeNames, pascalCase)
+	fieldNames := orDefault(r.FieldNames, camelCase)
+	argumentNames := orDefault(r.ArgumentNames, camelCase)
+	enumValueNames := orDefault(r.EnumValueNames, upperCase)
+	directiveNames := orDefault(r.DirectiveNames, camelCase)
+
+	errs := qerrors.ErrorList{}
+	checkArgs := func(coordinate string, args schema.InputValueList) {
+		for _, arg := range args {
+			if !argumentNames.MatchString(arg.Name) {
+				errs = append(errs, errorf(arg.NameLoc, "Argument \"%s(%s:)\" does not match the naming convention %s", coordinate, arg.Name, argumentNames))
+			}
+		}
+	}
+	for _, t := range namedTypes(s) {
+		if !typeNames.MatchString(t.TypeName()) {
+			errs = append(errs, errorf(typeLoc(t), "Type %q does not match the naming convention %s", t.TypeName(), typeNames))
+		}
+		for _, f := range fields(t) {
+			coordinate := t.TypeName() + "." + f.Name
+			if !fieldNames.MatchString(f.Name) {
+				errs = append(errs, errorf(f.Loc, "Field %q does not match the naming convention %s", coordinate, fieldNames))
+			}
+			checkArgs(coordinate, f.Args)
+		}
+		switch t := t.(type) {
+		case *schema.InputObject:
+			for _, f := range t.Fields {
+				if !fieldNames.MatchString(f.Name) {
+					errs = append(errs, errorf(f.NameLoc, "Input field \"%s.%s\" does not match the naming convention %s", t.Name, f.Name, fieldNames))
+				}
+			}
+		case *schema.Enum:
+			for _, v := range t.Values {
+				if !enumValueNames.MatchString(v.Name) {
+					errs = append(errs, errorf(v.Loc, "Enum value \"%s.%s\" does not match the naming convention %s", t.Name, v.Name, enumValueNames))
+				}
+			}
+		}
+	}
+	for _, d := range directives(s) {
+		if !directiveNames.MatchString(d.Name) {
+			errs = append(errs, errorf(d.Loc, "Directive \"@%s\" does not match the naming convention %s", d.Name, directiveNames))
+		}
+		checkArgs("@"+d.Name, d.Args)
+	}
+	return errs
+}
+
+// RequiredDescriptions checks that the enabled kinds of schema elements have a description.  Fields covers
+// both the fields of object and interface types and the fields of input types.
+type RequiredDescriptions struct {
+	Types      bool
+	Fields     bool
+	Arguments  bool
+	EnumValues bool
+	Directives bool
+}
+
+func (r *RequiredDescriptions) Name() string { return "RequiredDescriptions" }
+
+func (r *RequiredDescriptions) Check(s *schema.Schema) qerrors.ErrorList {
+	errs := qerrors.ErrorList{}
+	missing := func(desc schema.Description) bool {
+		return strings.TrimSpace(desc.Text) == ""
+	}
+	checkArgs := func(coordinate string, args schema.InputValueList) {
+		if !r.Arguments {
+			return
+		}
+		for _, arg := range args {
+			if missing(arg.Desc) {
+				errs = append(errs, errorf(arg.NameLoc, "Argument \"%s(%s:)\" has no description", coordinate, arg.Name))
+			}
+		}
+	}
+	for _, t := range namedTypes(s) {
+		if r.Types && strings.TrimSpace(t.Description()) == "" {
+			errs = append(errs, errorf(typeLoc(t), "Type %q has no description", t.TypeName()))
+		}
+		for _, f := range fields(t) {
+			coordinate := t.TypeName() + "." + f.Name
+			if r.Fields && missing(f.Desc) {
+				errs = append(errs, errorf(f.Loc, "Field %q has no description", coordinate))
+			}
+			checkArgs(coordinate, f.Args)
+		}
+		switch t := t.(type) {
+		case *schema.InputObject:
+			for _, f := range t.Fields {
+				if r.Fields && missing(f.Desc) {
+					errs = append(errs, errorf(f.NameLoc, "Input field \"%s.%s\" has no description", t.Name, f.Name))
+				}
+			}
+		case *schema.Enum:
+			for _, v := range t.Values {
+				if r.EnumValues && missing(v.Desc) {
+					errs = append(errs, errorf(v.Loc, "Enum value \"%s.%s\" has no description", t.Name, v.Name))
+				}
+			}
+		}
+	}
+	for _, d := range directives(s) {
+		if r.Directives && missing(d.Desc) {
+			errs = append(errs, errorf(d.Loc, "Directive \"@%s\" has no description", d.Name))
+		}
+		checkArgs("@"+d.Name, d.Args)
+	}
+	return errs
+}
+
+// RelayConnections checks that the object types named with the `Connection` suffix have the shape of
+// the Relay cursor connections specification: an `edges` list of objects with `node` and `cursor`
+// fields and a `pageInfo: PageInfo!` field.  The fields returning a connection must accept the `first`
+// and `after` or the `last` and `before` pagination arguments.
+type RelayConnections struct{}
+
+func (r *RelayConnections) Name() string { return "RelayConnections" }
+
+func (r *RelayConnections) Check(s *schema.Schema) qerrors.ErrorList {
+	errs := qerrors.ErrorList{}
+	for _, t := range namedTypes(s) {
+		if obj, ok := t.(*schema.Object); ok && isConnection(obj) {
+			errs = append(errs, checkConnection(obj)...)
+		}
+		for _, f := range fields(t) {
+			connection, ok := nullable(f.Type).(*schema.Object)
+			if !ok || !isConnection(connection) {
+				continue
+			}
+			forward := f.Args.Get("first") != nil && f.Args.Get("after") != nil
+			backward := f.Args.Get("last") != nil && f.Args.Get("before") != nil
+			if !forward && !backward {
+				errs = append(errs, errorf(f.Loc, "Field \"%s.%s\" returns the connection %q, it must have the \"first\" and \"after\" or the \"last\" and \"before\" arguments", t.TypeName(), f.Name, connection.Name))
+			}
+		}
+	}
+	if pageInfo, ok := s.Types["PageInfo"].(*schema.Object); ok {
+		for _, name := range []string{"hasNextPage", "hasPreviousPage"} {
+			if f := pageInfo.Fields.Get(name); f == nil || f.Type.String() != "Boolean!" {
+				errs = append(errs, errorf(pageInfo.Loc, "Type \"PageInfo\" must have a \"%s: Boolean!\" field", name))
+			}
+		}
+	}
+	return errs
+}
+
+func isConnection(t *schema.Object) bool {
+	return strings.HasSuffix(t.Name, "Connection") && t.Name != "Connection"
+}
+
+func checkConnection(t *schema.Object) qerrors.ErrorList {
+	errs := qerrors.ErrorList{}
+	if f := t.Fields.Get("pageInfo"); f == nil || f.Type.String() != "PageInfo!" {
+		errs = append(errs, errorf(t.Loc, "Connection %q must have a \"pageInfo: PageInfo!\" field", t.Name))
+	}
+	edges := t.Fields.Get("edges")
+	if edges == nil {
+		return append(errs, errorf(t.Loc, "Connection %q must have an \"edges\" field", t.Name))
+	}
+	list, ok := nullable(edges.Type).(*schema.List)
+	if !ok {
+		return append(errs, errorf(edges.Loc, "Field \"%s.edges\" must return a list", t.Name))
+	}
+	edge, ok := nullable(list.OfType).(*schema.Object)
+	if !ok {
+		return append(errs, errorf(edges.Loc, "Field \"%s.edges\" must return a list of edge objects", t.Name))
+	}
+	for _, name := range []string{"node", "cursor"} {
+		if edge.Fields.Get(name) == nil {
+			errs = append(errs, errorf(edge.Loc, "Edge %q must have a %q field", edge.Name, name))
+		}
+	}
+	return errs
+}
+
+func nullable(t schema.Type) schema.Type {
+	if nn, ok := t.(*schema.NonNull); ok {
+		return nn.OfType
+	}
+	return t
+}
+
+// UnusedTypes checks that every type can be reached from the query, mutation or subscription types, or
+// from the arguments of a directive.
+type UnusedTypes struct{}
+
+func (r *UnusedTypes) Name() string { return "UnusedTypes" }
+
+func (r *UnusedTypes) Check(s *schema.Schema) qerrors.ErrorList {
+	used := map[string]bool{}
+	var visit func(t schema.Type)
+	visitArgs := func(args schema.InputValueList) {
+		for _, arg := range args {
+			visit(arg.Type)
+		}
+	}
+	visit = func(t schema.Type) {
+		named, ok := schema.DeepestType(t).(schema.NamedType)
+		if !ok || used[named.TypeName()] {
+			return
+		}
+		used[named.TypeName()] = true
+		for _, f := range fields(named) {
+			visit(f.Type)
+			visitArgs(f.Args)
+		}
+		switch t := named.(type) {
+		case *schema.Object:
+			for _, intf := range t.Interfaces {
+				visit(intf)
+			}
+		case *schema.Interface:
+			for _, intf := range t.Interfaces {
+				visit(intf)
+			}
+			for _, obj := range t.PossibleTypes {
+				visit(obj)
+			}
+		case *schema.Union:
+			for _, obj := range t.PossibleTypes {
+				visit(obj)
+			}
+		case *schema.InputObject:
+			visitArgs(t.Fields)
+		}
+	}
+	for _, t := range s.EntryPoints {
+		visit(t)
+	}
+	for _, d := range s.DeclaredDirectives {
+		visitArgs(d.Args)
+	}
+
+	errs := qerrors.ErrorList{}
+	for _, t := range namedTypes(s) {
+		if !used[t.TypeName()] {
+			errs = append(errs, errorf(typeLoc(t), "Type %q is not used", t.TypeName()))
+		}
+	}
+	return errs
+}
+
+// InputOutputNames checks that input types, and only input types, are named with the InputSuffix, which
+// defaults to `Input`.
+type InputOutputNames struct {
+	InputSuffix string
+}
+
+func (r *InputOutputNames) Name() string { return "InputOutputNames" }
+
+func (r *InputOutputNames) Check(s *schema.Schema) qerrors.ErrorList {
+	suffix := r.InputSuffix
+	if suffix == "" {
+		suffix = "Input"
+	}
+	errs := qerrors.ErrorList{}
+	for _, t := range namedTypes(s) {
+		hasSuffix := strings.HasSuffix(t.TypeName(), suffix)
+		switch t.(type) {
+		case *schema.InputObject:
+			if !hasSuffix {
+				errs = append(errs, errorf(typeLoc(t), "Input type %q must be named with the %q suffix", t.TypeName(), suffix))
+			}
+		case *schema.Object, *schema.Interface, *schema.Union:
+			if hasSuffix {
+				errs = append(errs, errorf(typeLoc(t), "Output type %q must not be named with the %q suffix", t.TypeName(), suffix))
+			}
+		}
+	}
+	return errs
+}
+
+// DeprecationReasons checks that every use of @deprecated gives a reason other than the default one.
+type DeprecationReasons struct{}
+
+func (r *DeprecationReasons) Name() string { return "DeprecationReasons" }
+
+func (r *DeprecationReasons) Check(s *schema.Schema) qerrors.ErrorList {
+	defaultReason := ""
+	if decl := s.DeclaredDirectives["deprecated"]; decl != nil {
+		if arg := decl.Args.Get("reason"); arg != nil && arg.Default != nil {
+			defaultReason, _ = arg.Default.Evaluate(nil).(string)
+		}
+	}
+
+	errs := qerrors.ErrorList{}
+	check := func(coordinate string, directives schema.DirectiveList) {
+		d := directives.Get("deprecated")
+		if d == nil {
+			return
+		}
+		reason := ""
+		if arg, ok := d.Args.Get("reason"); ok && arg != nil {
+			reason, _ = arg.Evaluate(nil).(string)
+		}
+		if strings.TrimSpace(reason) == "" || reason == defaultReason {
+			errs = append(errs, errorf(d.NameLoc, "%q is deprecated without a reason", coordinate))
+		}
+	}
+	checkArgs := func(coordinate string, args schema.InputValueList) {
+		for _, arg := range args {
+			check(coordinate+"("+arg.Name+":)", arg.Directives)
+		}
+	}
+	for _, t := range namedTypes(s) {
+		for _, f := range fields(t) {
+			coordinate := t.TypeName() + "." + f.Name
+			check(coordinate, f.Directives)
+			checkArgs(coordinate, f.Args)
+		}
+		switch t := t.(type) {
+		case *schema.InputObject:
+			for _, f := range t.Fields {
+				check(t.Name+"."+f.Name, f.Directives)
+			}
+		case *schema.Enum:
+			for _, v := range t.Values {
+				check(t.Name+"."+v.Name, v.Directives)
+			}
+		}
+	}
+	for _, d := range directives(s) {
+		checkArgs("@"+d.Name, d.Args)
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/chirino/graphql/internal/lru"
+	"github.com/chirino/graphql/qerrors"
+)
+
+// Error messages used by the automatic persisted query protocol, see:
+// https://github.com/apollographql/apollo-link-persisted-queries
+const (
+	PersistedQueryNotFound     = "PersistedQueryNotFound"
+	PersistedQueryNotSupported = "PersistedQueryNotSupported"
+)
+
+// PersistedQueryStore holds query documents keyed by the hex encoded SHA-256 hash of the query text.
+// Implementations must be safe for concurrent use.
+type PersistedQueryStore interface {
+	Get(hash string) (query string, found bool)
+	Put(hash string, query string)
+}
+
+type lruPersistedQueryStore struct {
+	cache *lru.Cache
+}
+
+// NewPersistedQueryLRU creates an in memory PersistedQueryStore that holds up to size queries.
+func NewPersistedQueryLRU(size int) PersistedQueryStore {
+	return &lruPersistedQueryStore{cache: lru.New(size)}
+}
+
+func (s *lruPersistedQueryStore) Get(hash string) (string, bool) {
+	query, found := s.cache.Get(hash)
+	if !found {
+		return "", false
+	}
+	return query.(string), true
+}
+
+func (s *lruPersistedQueryStore) Put(hash string, query string) {
+	s.cache.Add(hash, query)
+}
+
+// PersistedQueryHash computes the hash used to identify a persisted query.
+func PersistedQueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// HasErrorMessage returns true if any of the errors has the given message.
+func HasErrorMessage(errs ErrorList, message string) bool {
+	for _, err := range errs {
+		if err.Message == message {
+			return true
+		}
+	}
+	return false
+}
+
+func persistedQueryError(message string, code string) *qerrors.Error {
+	return qerrors.New(message).WithExtensions(map[string]interface{}{
+		"code": code,
+	})
+}
+
+// resolvePersistedQuery fills in or registers the query text of a request that uses
+// the persistedQuery extension.
+func (engine *Engine) resolvePersistedQuery(request *Request) (*Request, error) {
+	hash, version, ok := request.PersistedQuery()
+	if !ok {
+		return request, nil
+	}
+	if engine.PersistedQueries == nil {
+		if request.Query == "" {
+			return nil, persistedQueryError(PersistedQueryNotSupported, "PERSISTED_QUERY_NOT_SUPPORTED")
+		}
+		return request, nil
+	}
+	if version != 1 {
+		return nil, qerrors.Errorf("unsupported persisted query version: %v", version)
+	}
+
+	if request.Query == "" {
+		query, found := engine.PersistedQueries.Get(hash)
+		if !found {
+			return nil, persistedQueryError(PersistedQueryNotFound, "PERSISTED_QUERY_NOT_FOUND")
+		}
+		requestCp := *request
+		requestCp.Query = query
+		return &requestCp, nil
+	}
+
+	if PersistedQueryHash(request.Query) != hash {
+		return nil, qerrors.New("provided sha does not match query")
+	}
+	engine.PersistedQueries.Put(hash, request.Query)
+	return request, nil
+}
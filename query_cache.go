@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"sync/atomic"
+
+	"github.com/chirino/graphql/internal/lru"
+	"github.com/chirino/graphql/schema"
+)
+
+// QueryCache holds parsed and validated query documents so that repeated queries
+// can skip parsing and validation.  Cached documents are shared between concurrent
+// requests and are never returned to the field pool, so they must not be modified.
+//
+// The cache is keyed on the schema instance, so call Purge if you modify the schema
+// after requests have been served.
+type QueryCache struct {
+	cache  *lru.Cache
+	hits   uint64
+	misses uint64
+}
+
+type queryCacheKey struct {
+	schema   *schema.Schema
+	maxDepth int
+	query    string
+}
+
+type queryCacheEntry struct {
+	doc         *schema.QueryDocument
+	validateErr error
+}
+
+// NewQueryCache creates a QueryCache that holds up to size query documents.
+func NewQueryCache(size int) *QueryCache {
+	return &QueryCache{cache: lru.New(size)}
+}
+
+// Hits returns the number of lookups that found a cached document.
+func (c *QueryCache) Hits() uint64 {
+	return atomic.LoadUint64(&c.hits)
+}
+
+// Misses returns the number of lookups that did not find a cached document.
+func (c *QueryCache) Misses() uint64 {
+	return atomic.LoadUint64(&c.misses)
+}
+
+// Len returns the number of cached documents.
+func (c *QueryCache) Len() int {
+	return c.cache.Len()
+}
+
+// Purge removes all cached documents.
+func (c *QueryCache) Purge() {
+	c.cache.Purge()
+}
+
+func (c *QueryCache) get(key queryCacheKey) *queryCacheEntry {
+	entry, found := c.cache.Get(key)
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		return nil
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return entry.(*queryCacheEntry)
+}
+
+func (c *QueryCache) add(key queryCacheKey, entry *queryCacheEntry) {
+	c.cache.Add(key, entry)
+}
@@ -17,6 +17,14 @@ type Request struct {
 	OperationName string `json:"operationName,omitempty"`
 	// Variables can be set to a json.RawMessage or a map[string]interface{}
 	Variables interface{} `json:"variables,omitempty"`
+	// Extensions holds protocol extensions like the persistedQuery hash.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+	// IncrementalDelivery is set by callers that can handle the multiple responses produced by
+	// the @defer and @stream directives.  Those directives are ignored when it's not set.
+	IncrementalDelivery bool `json:"-"`
+	// ReadOnly is set by callers, like HTTP GET handlers, whose requests must not have side effects.
+	// Mutation operations are rejected when it's set.
+	ReadOnly bool `json:"-"`
 }
 
 func (r Request) GetContext() (ctx context.Context) {
@@ -60,3 +68,30 @@ func (r *Request) VariablesAsJson() (json.RawMessage, error) {
 	}
 	return nil, fmt.Errorf("unsupported type: %s", reflect.TypeOf(r.Variables))
 }
+
+// PersistedQuery returns the hash and version found in the persistedQuery extension of the request.
+func (r *Request) PersistedQuery() (hash string, version float64, ok bool) {
+	pq, _ := r.Extensions["persistedQuery"].(map[string]interface{})
+	if pq == nil {
+		return "", 0, false
+	}
+	hash, _ = pq["sha256Hash"].(string)
+	switch v := pq["version"].(type) {
+	case float64:
+		version = v
+	case int:
+		version = float64(v)
+	}
+	return hash, version, hash != ""
+}
+
+// SetPersistedQuery sets the persistedQuery extension of the request.
+func (r *Request) SetPersistedQuery(hash string) {
+	if r.Extensions == nil {
+		r.Extensions = map[string]interface{}{}
+	}
+	r.Extensions["persistedQuery"] = map[string]interface{}{
+		"version":    1,
+		"sha256Hash": hash,
+	}
+}
@@ -0,0 +1,178 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+///////////////////////////////////////////////////////////////////////
+//
+// BatchResolver collects the keys of sibling field resolutions and
+// loads them with a single call to a batch function, in the style of
+// dataloader.  Results are cached for the rest of the execution.
+//
+///////////////////////////////////////////////////////////////////////
+type BatchResolver struct {
+	// Key returns the key that should be loaded to resolve the request.  Return false
+	// if this resolver should not handle the request.
+	Key func(request *ResolveRequest) (key interface{}, ok bool)
+	// Load fetches the values for the keys.  It must return one value per key in the same
+	// order as the keys.  errs can be nil or hold one error per key.  ctx is done once the
+	// first of the batched fields times out.
+	Load func(ctx context.Context, keys []interface{}) (values []interface{}, errs []error)
+	// MaxBatchSize limits the number of keys passed to Load, 0 means no limit.
+	MaxBatchSize int
+}
+
+type batchResult struct {
+	value reflect.Value
+	err   error
+}
+
+type batch struct {
+	keys []interface{}
+	// deadlines holds the deadline of the field context of each key, the zero time if it has none.
+	deadlines []time.Time
+	results   map[interface{}]*batchResult
+	done      chan struct{}
+}
+
+type batchState struct {
+	mu      sync.Mutex
+	pending *batch
+	loaded  map[interface{}]*batch
+}
+
+func (factory *BatchResolver) Resolve(request *ResolveRequest, next Resolution) Resolution {
+	key, ok := factory.Key(request)
+	if !ok {
+		return next
+	}
+
+	// each execution gets it's own state, so results are only cached per request.  Keys of executions
+	// that don't hold shared values are loaded on their own.
+	values := &sync.Map{}
+	if ev, ok := request.ExecutionContext.(ExecutionValues); ok {
+		values = ev.GetValues()
+	}
+	state, found := values.Load(factory)
+	if !found {
+		state, _ = values.LoadOrStore(factory, &batchState{loaded: map[interface{}]*batch{}})
+	}
+	s := state.(*batchState)
+
+	s.mu.Lock()
+	b := s.loaded[key]
+	if b == nil {
+		// queue up the key, it gets loaded when the first resolution of the batch is executed.
+		if s.pending == nil {
+			s.pending = &batch{done: make(chan struct{})}
+		}
+		b = s.pending
+		b.keys = append(b.keys, key)
+		b.deadlines = append(b.deadlines, deadline(request))
+		s.loaded[key] = b
+	}
+	s.mu.Unlock()
+
+	return func() (reflect.Value, error) {
+		s.mu.Lock()
+		if s.pending == b {
+			s.pending = nil
+			s.mu.Unlock()
+			factory.dispatch(request, b)
+		} else {
+			s.mu.Unlock()
+		}
+		<-b.done
+		r := b.results[key]
+		return r.value, r.err
+	}
+}
+
+func (factory *BatchResolver) dispatch(request *ResolveRequest, b *batch) {
+	b.results = make(map[interface{}]*batchResult, len(b.keys))
+
+	chunkSize := factory.MaxBatchSize
+	if chunkSize <= 0 {
+		chunkSize = len(b.keys)
+	}
+
+	// Load the chunks async, but stay within the concurrency limits of the execution.
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	for i := 0; i < len(b.keys); i += chunkSize {
+		end := i + chunkSize
+		if end > len(b.keys) {
+			end = len(b.keys)
+		}
+		keys := b.keys[i:end]
+		deadlines := b.deadlines[i:end]
+
+		wg.Add(1)
+		*request.ExecutionContext.GetLimiter() <- 1
+		go func() {
+			results := map[interface{}]*batchResult{}
+			defer func() {
+				if err := request.ExecutionContext.HandlePanic(request.SelectionPath()); err != nil {
+					for _, key := range keys {
+						results[key] = &batchResult{err: err}
+					}
+				}
+				<-*request.ExecutionContext.GetLimiter()
+				mu.Lock()
+				for key, result := range results {
+					b.results[key] = result
+				}
+				mu.Unlock()
+				wg.Done()
+			}()
+
+			// the fields of the keys time out on their own, the load gives up once the first one does.
+			ctx := request.ExecutionContext.GetContext()
+			if d := earliest(deadlines); !d.IsZero() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, d)
+				defer cancel()
+			}
+			values, errs := factory.Load(ctx, keys)
+			for i, key := range keys {
+				result := &batchResult{}
+				switch {
+				case errs != nil && i < len(errs) && errs[i] != nil:
+					result.err = errs[i]
+				case i < len(values):
+					result.value = reflect.ValueOf(values[i])
+				default:
+					result.err = fmt.Errorf("batch load returned %d values for %d keys", len(values), len(keys))
+				}
+				results[key] = result
+			}
+		}()
+	}
+	wg.Wait()
+	close(b.done)
+}
+
+// deadline returns the deadline of the field context of the request.
+func deadline(request *ResolveRequest) time.Time {
+	if request.Context == nil {
+		return time.Time{}
+	}
+	d, _ := request.Context.Deadline()
+	return d
+}
+
+// earliest returns the earliest of the deadlines, ignoring the zero ones.
+func earliest(deadlines []time.Time) time.Time {
+	result := time.Time{}
+	for _, d := range deadlines {
+		if !d.IsZero() && (result.IsZero() || d.Before(result)) {
+			result = d
+		}
+	}
+	return result
+}
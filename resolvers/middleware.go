@@ -0,0 +1,65 @@
+package resolvers
+
+import (
+	"reflect"
+	"time"
+)
+
+// Middleware is called around the Resolver chain of every field.  Use it to implement concerns like auth,
+// logging, metrics or caching once for all the fields.  It runs before the Resolver chain is asked for the
+// Resolution, so it can fail a field before any resolver, including the ones that use RunAsync, starts
+// working on it.
+type Middleware interface {
+	// Wrap returns the Resolution of the field, usually by calling resolve and wrapping the Resolution it
+	// returns.  resolve returns nil if no resolver could resolve the field.
+	Wrap(request *ResolveRequest, resolve func() Resolution) Resolution
+}
+
+type MiddlewareFunc func(request *ResolveRequest, resolve func() Resolution) Resolution
+
+func (f MiddlewareFunc) Wrap(request *ResolveRequest, resolve func() Resolution) Resolution {
+	return f(request, resolve)
+}
+
+// Hooks is a Middleware that calls functions before and after the resolution of each field.
+type Hooks struct {
+	// Before is called before the Resolver chain is asked to resolve the field.  Returning an error fails
+	// the field without resolving it.
+	Before func(request *ResolveRequest) error
+	// After is called with the result of the field and how long it took to resolve, including Before.
+	// It returns the result that gets used, so it can also replace the value or the error.
+	After func(request *ResolveRequest, value reflect.Value, err error, duration time.Duration) (reflect.Value, error)
+}
+
+func (h Hooks) Wrap(request *ResolveRequest, resolve func() Resolution) Resolution {
+	start := time.Now()
+	var resolution Resolution
+	if h.Before != nil {
+		if err := h.Before(request); err != nil {
+			resolution = func() (reflect.Value, error) {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	if resolution == nil {
+		resolution = resolve()
+	}
+	if resolution == nil || h.After == nil {
+		return resolution
+	}
+	return func() (reflect.Value, error) {
+		value, err := resolution()
+		return h.After(request, value, err, time.Since(start))
+	}
+}
+
+// ResolveWithMiddleware calls resolve through the middleware, the first middleware is the outermost one.
+func ResolveWithMiddleware(middleware []Middleware, request *ResolveRequest, resolve func() Resolution) Resolution {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		m, next := middleware[i], resolve
+		resolve = func() Resolution {
+			return m.Wrap(request, next)
+		}
+	}
+	return resolve()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"sync"
 
 	"github.com/chirino/graphql/schema"
 )
@@ -22,6 +23,12 @@ type ExecutionContext interface {
 	GetVars() map[string]interface{}
 }
 
+// ExecutionValues is implemented by the ExecutionContexts that hold values shared by all the resolvers
+// of the execution.
+type ExecutionValues interface {
+	GetValues() *sync.Map
+}
+
 type ResolveRequest struct {
 	Context          context.Context
 	ExecutionContext ExecutionContext
@@ -32,6 +39,9 @@ type ResolveRequest struct {
 	Field            *schema.Field
 	Args             map[string]interface{}
 	Selection        *schema.FieldSelection
+	// Serial is true for the root fields of mutations.  Their Resolution is requested right before it
+	// gets executed, and after the Resolution of the previous root field has completed.
+	Serial bool
 }
 
 type Resolution func() (reflect.Value, error)
@@ -14,6 +14,12 @@ type Response struct {
 	Errors     ErrorList              `json:"errors,omitempty"`
 	Extensions interface{}            `json:"extensions,omitempty"`
 	Details    map[string]interface{} `json:"-"`
+
+	// Path, Label and HasNext are set on the payloads of a query that uses the @defer or @stream
+	// directives.  Path is a []interface{} of field names and list indexes.
+	Path    interface{} `json:"path,omitempty"`
+	Label   string      `json:"label,omitempty"`
+	HasNext *bool       `json:"hasNext,omitempty"`
 }
 
 func NewResponse() *Response {
@@ -0,0 +1,388 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Criticality classifies how a schema change affects the clients of the old schema.
+type Criticality string
+
+const (
+	// Breaking changes make queries that were valid against the old schema fail.
+	Breaking Criticality = "BREAKING"
+	// Dangerous changes keep queries valid but can change the results clients get, for example
+	// a new enum value they don't know how to handle.
+	Dangerous Criticality = "DANGEROUS"
+	// Safe changes don't affect existing clients.
+	Safe Criticality = "SAFE"
+)
+
+// ChangeType identifies the kind of a schema change.
+type ChangeType string
+
+const (
+	TypeRemoved                ChangeType = "TYPE_REMOVED"
+	TypeAdded                  ChangeType = "TYPE_ADDED"
+	TypeKindChanged            ChangeType = "TYPE_KIND_CHANGED"
+	EntryPointChanged          ChangeType = "ENTRY_POINT_CHANGED"
+	FieldRemoved               ChangeType = "FIELD_REMOVED"
+	FieldAdded                 ChangeType = "FIELD_ADDED"
+	FieldTypeChanged           ChangeType = "FIELD_TYPE_CHANGED"
+	ArgRemoved                 ChangeType = "ARG_REMOVED"
+	ArgAdded                   ChangeType = "ARG_ADDED"
+	ArgTypeChanged             ChangeType = "ARG_TYPE_CHANGED"
+	ArgDefaultChanged          ChangeType = "ARG_DEFAULT_CHANGED"
+	InputFieldRemoved          ChangeType = "INPUT_FIELD_REMOVED"
+	InputFieldAdded            ChangeType = "INPUT_FIELD_ADDED"
+	InputFieldTypeChanged      ChangeType = "INPUT_FIELD_TYPE_CHANGED"
+	InputFieldDefaultChanged   ChangeType = "INPUT_FIELD_DEFAULT_CHANGED"
+	EnumValueRemoved           ChangeType = "ENUM_VALUE_REMOVED"
+	EnumValueAdded             ChangeType = "ENUM_VALUE_ADDED"
+	UnionMemberRemoved         ChangeType = "UNION_MEMBER_REMOVED"
+	UnionMemberAdded           ChangeType = "UNION_MEMBER_ADDED"
+	InterfaceRemoved           ChangeType = "INTERFACE_REMOVED"
+	InterfaceAdded             ChangeType = "INTERFACE_ADDED"
+	DeprecationAdded           ChangeType = "DEPRECATION_ADDED"
+	DeprecationRemoved         ChangeType = "DEPRECATION_REMOVED"
+	DirectiveRemoved           ChangeType = "DIRECTIVE_REMOVED"
+	DirectiveAdded             ChangeType = "DIRECTIVE_ADDED"
+	DirectiveLocationRemoved   ChangeType = "DIRECTIVE_LOCATION_REMOVED"
+	DirectiveLocationAdded     ChangeType = "DIRECTIVE_LOCATION_ADDED"
+	DirectiveRepeatableRemoved ChangeType = "DIRECTIVE_REPEATABLE_REMOVED"
+	DirectiveRepeatableAdded   ChangeType = "DIRECTIVE_REPEATABLE_ADDED"
+)
+
+// Change describes a single difference between two schemas.  Path is the dotted path of the changed
+// element, like `Query.search.filter` for the `filter` argument of the `Query.search` field, or
+// `@include.if` for an argument of a directive.
+type Change struct {
+	Type        ChangeType  `json:"type"`
+	Criticality Criticality `json:"criticality"`
+	Path        string      `json:"path"`
+	Message     string      `json:"message"`
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Criticality, c.Message)
+}
+
+type ChangeList []*Change
+
+// Filter returns the changes of the given criticality.
+func (l ChangeList) Filter(criticality Criticality) ChangeList {
+	rc := ChangeList{}
+	for _, c := range l {
+		if c.Criticality == criticality {
+			rc = append(rc, c)
+		}
+	}
+	return rc
+}
+
+// Breaking returns the changes that break the clients of the old schema.
+func (l ChangeList) Breaking() ChangeList {
+	return l.Filter(Breaking)
+}
+
+// Diff compares the types, fields, arguments, enum values and directives of two resolved schemas
+// and returns the changes needed to go from the old one to the new one, ordered by path.
+func Diff(old, new *Schema) ChangeList {
+	d := &differ{}
+
+	for _, op := range []OperationType{Query, Mutation, Subscription} {
+		oldName, newName := old.EntryPointNames[op], new.EntryPointNames[op]
+		if oldName != "" && newName == "" {
+			d.add(EntryPointChanged, Breaking, string(op), "Schema %s root type %q was removed", op, oldName)
+		} else if oldName != "" && oldName != newName {
+			d.add(EntryPointChanged, Breaking, string(op), "Schema %s root type changed from %q to %q", op, oldName, newName)
+		} else if oldName == "" && newName != "" {
+			d.add(EntryPointChanged, Safe, string(op), "Schema %s root type %q was added", op, newName)
+		}
+	}
+
+	for _, name := range typeNames(old.Types, new.Types) {
+		oldType, newType := old.Types[name], new.Types[name]
+		switch {
+		case newType == nil:
+			d.add(TypeRemoved, Breaking, name, "Type %q was removed", name)
+		case oldType == nil:
+			d.add(TypeAdded, Safe, name, "Type %q was added", name)
+		case oldType.Kind() != newType.Kind():
+			d.add(TypeKindChanged, Breaking, name, "Type %q changed from %s to %s", name, oldType.Kind(), newType.Kind())
+		default:
+			d.diffType(oldType, newType)
+		}
+	}
+
+	for _, name := range directiveNames(old.DeclaredDirectives, new.DeclaredDirectives) {
+		oldDirective, newDirective := old.DeclaredDirectives[name], new.DeclaredDirectives[name]
+		path := "@" + name
+		switch {
+		case newDirective == nil:
+			d.add(DirectiveRemoved, Breaking, path, "Directive %q was removed", path)
+		case oldDirective == nil:
+			d.add(DirectiveAdded, Safe, path, "Directive %q was added", path)
+		default:
+			d.diffDirective(path, oldDirective, newDirective)
+		}
+	}
+
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Path < d.changes[j].Path
+	})
+	return d.changes
+}
+
+type differ struct {
+	changes ChangeList
+}
+
+func (d *differ) add(changeType ChangeType, criticality Criticality, path string, format string, args ...interface{}) {
+	d.changes = append(d.changes, &Change{
+		Type:        changeType,
+		Criticality: criticality,
+		Path:        path,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *differ) diffType(oldType, newType NamedType) {
+	switch oldType := oldType.(type) {
+	case *Object:
+		newType := newType.(*Object)
+		d.diffInterfaces(oldType.Name, oldType.InterfaceNames, newType.InterfaceNames)
+		d.diffFields(oldType.Name, oldType.Fields, newType.Fields)
+	case *Interface:
+		newType := newType.(*Interface)
+		d.diffInterfaces(oldType.Name, oldType.InterfaceNames, newType.InterfaceNames)
+		d.diffFields(oldType.Name, oldType.Fields, newType.Fields)
+	case *Union:
+		newType := newType.(*Union)
+		for _, name := range sortedNames(oldType.TypeNames, newType.TypeNames) {
+			path := oldType.Name + "." + name
+			switch {
+			case StringListGet(newType.TypeNames, name) == nil:
+				d.add(UnionMemberRemoved, Breaking, path, "Type %q was removed from union %q", name, oldType.Name)
+			case StringListGet(oldType.TypeNames, name) == nil:
+				d.add(UnionMemberAdded, Dangerous, path, "Type %q was added to union %q", name, oldType.Name)
+			}
+		}
+	case *Enum:
+		newType := newType.(*Enum)
+		for _, value := range oldType.Values {
+			path := oldType.Name + "." + value.Name
+			newValue := newType.Values.Get(value.Name)
+			if newValue == nil {
+				d.add(EnumValueRemoved, Breaking, path, "Enum value %q was removed", path)
+				continue
+			}
+			d.diffDeprecation(path, value.Directives, newValue.Directives)
+		}
+		for _, value := range newType.Values {
+			if oldType.Values.Get(value.Name) == nil {
+				path := oldType.Name + "." + value.Name
+				d.add(EnumValueAdded, Dangerous, path, "Enum value %q was added", path)
+			}
+		}
+	case *InputObject:
+		newType := newType.(*InputObject)
+		d.diffInputValues(oldType.Name, oldType.Fields, newType.Fields, inputFieldChanges)
+	}
+}
+
+func (d *differ) diffInterfaces(typeName string, oldNames, newNames []string) {
+	for _, name := range sortedNames(oldNames, newNames) {
+		path := typeName + "." + name
+		switch {
+		case StringListGet(newNames, name) == nil:
+			d.add(InterfaceRemoved, Breaking, path, "Type %q no longer implements %q", typeName, name)
+		case StringListGet(oldNames, name) == nil:
+			d.add(InterfaceAdded, Dangerous, path, "Type %q now implements %q", typeName, name)
+		}
+	}
+}
+
+func (d *differ) diffFields(typeName string, oldFields, newFields FieldList) {
+	for _, f := range oldFields {
+		path := typeName + "." + f.Name
+		newField := newFields.Get(f.Name)
+		if newField == nil {
+			d.add(FieldRemoved, Breaking, path, "Field %q was removed", path)
+			continue
+		}
+		if f.Type.String() != newField.Type.String() {
+			criticality := Breaking
+			if isSafeOutputTypeChange(f.Type, newField.Type) {
+				criticality = Safe
+			}
+			d.add(FieldTypeChanged, criticality, path, "Field %q changed type from %q to %q", path, f.Type, newField.Type)
+		}
+		d.diffDeprecation(path, f.Directives, newField.Directives)
+		d.diffInputValues(path, f.Args, newField.Args, argChanges)
+	}
+	for _, f := range newFields {
+		if oldFields.Get(f.Name) == nil {
+			path := typeName + "." + f.Name
+			d.add(FieldAdded, Safe, path, "Field %q was added", path)
+		}
+	}
+}
+
+// inputValueChanges names the change types used for either arguments or input fields.
+type inputValueChanges struct {
+	kind           string
+	removed        ChangeType
+	added          ChangeType
+	typeChanged    ChangeType
+	defaultChanged ChangeType
+}
+
+var argChanges = inputValueChanges{"Argument", ArgRemoved, ArgAdded, ArgTypeChanged, ArgDefaultChanged}
+var inputFieldChanges = inputValueChanges{"Input field", InputFieldRemoved, InputFieldAdded, InputFieldTypeChanged, InputFieldDefaultChanged}
+
+func (d *differ) diffInputValues(parentPath string, oldValues, newValues InputValueList, changes inputValueChanges) {
+	for _, v := range oldValues {
+		path := parentPath + "." + v.Name
+		newValue := newValues.Get(v.Name)
+		if newValue == nil {
+			d.add(changes.removed, Breaking, path, "%s %q was removed", changes.kind, path)
+			continue
+		}
+		if v.Type.String() != newValue.Type.String() {
+			criticality := Breaking
+			if isSafeInputTypeChange(v.Type, newValue.Type) {
+				criticality = Safe
+			}
+			d.add(changes.typeChanged, criticality, path, "%s %q changed type from %q to %q", changes.kind, path, v.Type, newValue.Type)
+		}
+		if literalString(v.Default) != literalString(newValue.Default) {
+			d.add(changes.defaultChanged, Dangerous, path, "%s %q changed default value from %s to %s", changes.kind, path, literalString(v.Default), literalString(newValue.Default))
+		}
+		d.diffDeprecation(path, v.Directives, newValue.Directives)
+	}
+	for _, v := range newValues {
+		if oldValues.Get(v.Name) != nil {
+			continue
+		}
+		path := parentPath + "." + v.Name
+		if _, required := v.Type.(*NonNull); required && v.Default == nil {
+			d.add(changes.added, Breaking, path, "%s %q was added as required", changes.kind, path)
+		} else {
+			d.add(changes.added, Dangerous, path, "%s %q was added as optional", changes.kind, path)
+		}
+	}
+}
+
+func (d *differ) diffDeprecation(path string, oldDirectives, newDirectives DirectiveList) {
+	wasDeprecated := oldDirectives.Get("deprecated") != nil
+	isDeprecated := newDirectives.Get("deprecated") != nil
+	if !wasDeprecated && isDeprecated {
+		d.add(DeprecationAdded, Safe, path, "%q was deprecated", path)
+	} else if wasDeprecated && !isDeprecated {
+		d.add(DeprecationRemoved, Safe, path, "%q is no longer deprecated", path)
+	}
+}
+
+func (d *differ) diffDirective(path string, oldDirective, newDirective *DirectiveDecl) {
+	for _, loc := range sortedNames(oldDirective.Locs, newDirective.Locs) {
+		switch {
+		case StringListGet(newDirective.Locs, loc) == nil:
+			d.add(DirectiveLocationRemoved, Breaking, path, "Location %s was removed from directive %q", loc, path)
+		case StringListGet(oldDirective.Locs, loc) == nil:
+			d.add(DirectiveLocationAdded, Safe, path, "Location %s was added to directive %q", loc, path)
+		}
+	}
+	if oldDirective.Repeatable && !newDirective.Repeatable {
+		d.add(DirectiveRepeatableRemoved, Breaking, path, "Directive %q is no longer repeatable", path)
+	} else if !oldDirective.Repeatable && newDirective.Repeatable {
+		d.add(DirectiveRepeatableAdded, Safe, path, "Directive %q is now repeatable", path)
+	}
+	d.diffInputValues(path, oldDirective.Args, newDirective.Args, argChanges)
+}
+
+// isSafeOutputTypeChange reports whether the values of the new type of a field can be handled by clients
+// that expect the old type, which is the case when the new type only adds non null constraints.
+func isSafeOutputTypeChange(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case *NonNull:
+		newType, ok := newType.(*NonNull)
+		return ok && isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+	case *List:
+		switch newType := newType.(type) {
+		case *NonNull:
+			return isSafeOutputTypeChange(oldType, newType.OfType)
+		case *List:
+			return isSafeOutputTypeChange(oldType.OfType, newType.OfType)
+		}
+		return false
+	default:
+		if newNonNull, ok := newType.(*NonNull); ok {
+			return isSafeOutputTypeChange(oldType, newNonNull.OfType)
+		}
+		return oldType.String() == newType.String()
+	}
+}
+
+// isSafeInputTypeChange reports whether the values clients send for the old type of an argument or input
+// field are still valid for the new type, which is the case when the new type only removes non null
+// constraints.
+func isSafeInputTypeChange(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case *NonNull:
+		if newNonNull, ok := newType.(*NonNull); ok {
+			return isSafeInputTypeChange(oldType.OfType, newNonNull.OfType)
+		}
+		return isSafeInputTypeChange(oldType.OfType, newType)
+	case *List:
+		newList, ok := newType.(*List)
+		return ok && isSafeInputTypeChange(oldType.OfType, newList.OfType)
+	default:
+		return oldType.String() == newType.String()
+	}
+}
+
+func literalString(l Literal) string {
+	if l == nil {
+		return "none"
+	}
+	return l.String()
+}
+
+func sortedNames(a, b []string) []string {
+	set := map[string]bool{}
+	for _, name := range a {
+		set[name] = true
+	}
+	for _, name := range b {
+		set[name] = true
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func typeNames(a, b map[string]NamedType) []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		names = append(names, name)
+	}
+	return sortedNames(names, nil)
+}
+
+func directiveNames(a, b map[string]*DirectiveDecl) []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		names = append(names, name)
+	}
+	return sortedNames(names, nil)
+}
@@ -0,0 +1,146 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/chirino/graphql/internal/example/starwars"
+	"github.com/chirino/graphql/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, sdl string) *schema.Schema {
+	s := schema.New()
+	require.NoError(t, s.Parse(sdl))
+	return s
+}
+
+func TestDiffOfSameSchemaIsEmpty(t *testing.T) {
+	assert.Empty(t, schema.Diff(mustParse(t, starwars.Schema), mustParse(t, starwars.Schema)))
+}
+
+func TestDiff(t *testing.T) {
+	old := mustParse(t, `
+		directive @cached(ttl: Int) on FIELD_DEFINITION | OBJECT
+		schema {
+			query: Query
+		}
+		type Query {
+			pet(id: ID, filter: Filter): Pet
+			pets(limit: Int = 10): [Pet]
+			owner: String
+		}
+		interface Named {
+			name: String
+		}
+		type Pet implements Named {
+			name: String
+			age: Int!
+		}
+		type Cat {
+			name: String
+		}
+		union Animal = Pet | Cat
+		enum Color { RED GREEN }
+		input Filter {
+			color: Color
+			name: String!
+		}
+		scalar Time
+	`)
+	new := mustParse(t, `
+		directive @cached(ttl: Int, scope: String) repeatable on FIELD_DEFINITION
+		schema {
+			query: Query
+		}
+		type Query {
+			pet(id: ID!, filter: Filter): Pet
+			pets(limit: Int = 20, offset: Int): [Pet!]
+			owner: Int
+		}
+		interface Named {
+			name: String
+		}
+		type Pet {
+			name: String @deprecated
+			age: Int
+			color: Color
+		}
+		type Cat {
+			name: String
+		}
+		union Animal = Pet
+		enum Color { RED BLUE }
+		input Filter {
+			color: Color
+			name: String
+			tag: String!
+		}
+		input Time {
+			value: String
+		}
+	`)
+
+	changes := schema.Diff(old, new)
+	actual := []string{}
+	for _, c := range changes {
+		actual = append(actual, string(c.Type)+" "+c.Path+" "+c.String())
+	}
+	assert.Equal(t, []string{
+		`DIRECTIVE_LOCATION_REMOVED @cached BREAKING: Location OBJECT was removed from directive "@cached"`,
+		`DIRECTIVE_REPEATABLE_ADDED @cached SAFE: Directive "@cached" is now repeatable`,
+		`ARG_ADDED @cached.scope DANGEROUS: Argument "@cached.scope" was added as optional`,
+		`UNION_MEMBER_REMOVED Animal.Cat BREAKING: Type "Cat" was removed from union "Animal"`,
+		`ENUM_VALUE_ADDED Color.BLUE DANGEROUS: Enum value "Color.BLUE" was added`,
+		`ENUM_VALUE_REMOVED Color.GREEN BREAKING: Enum value "Color.GREEN" was removed`,
+		`INPUT_FIELD_TYPE_CHANGED Filter.name SAFE: Input field "Filter.name" changed type from "String!" to "String"`,
+		`INPUT_FIELD_ADDED Filter.tag BREAKING: Input field "Filter.tag" was added as required`,
+		`INTERFACE_REMOVED Pet.Named BREAKING: Type "Pet" no longer implements "Named"`,
+		`FIELD_TYPE_CHANGED Pet.age BREAKING: Field "Pet.age" changed type from "Int!" to "Int"`,
+		`FIELD_ADDED Pet.color SAFE: Field "Pet.color" was added`,
+		`DEPRECATION_ADDED Pet.name SAFE: "Pet.name" was deprecated`,
+		`FIELD_TYPE_CHANGED Query.owner BREAKING: Field "Query.owner" changed type from "String" to "Int"`,
+		`ARG_TYPE_CHANGED Query.pet.id BREAKING: Argument "Query.pet.id" changed type from "ID" to "ID!"`,
+		`FIELD_TYPE_CHANGED Query.pets SAFE: Field "Query.pets" changed type from "[Pet]" to "[Pet!]"`,
+		`ARG_DEFAULT_CHANGED Query.pets.limit DANGEROUS: Argument "Query.pets.limit" changed default value from 10 to 20`,
+		`ARG_ADDED Query.pets.offset DANGEROUS: Argument "Query.pets.offset" was added as optional`,
+		`TYPE_KIND_CHANGED Time BREAKING: Type "Time" changed from SCALAR to INPUT_OBJECT`,
+	}, actual)
+
+	assert.Len(t, changes.Breaking(), 9)
+	assert.Len(t, changes.Filter(schema.Dangerous), 4)
+	assert.Len(t, changes.Filter(schema.Safe), 5)
+}
+
+func TestDiffOfRemovedTypesAndDirectives(t *testing.T) {
+	old := mustParse(t, `
+		directive @cached on FIELD_DEFINITION
+		schema {
+			query: Query
+			mutation: Mutation
+		}
+		type Query {
+			hello: String
+		}
+		type Mutation {
+			hello: String
+		}
+	`)
+	new := mustParse(t, `
+		schema {
+			query: Query
+		}
+		type Query {
+			hello: String
+		}
+	`)
+	changes := schema.Diff(old, new)
+	assert.Equal(t, schema.ChangeList{
+		{Type: schema.DirectiveRemoved, Criticality: schema.Breaking, Path: "@cached", Message: `Directive "@cached" was removed`},
+		{Type: schema.TypeRemoved, Criticality: schema.Breaking, Path: "Mutation", Message: `Type "Mutation" was removed`},
+		{Type: schema.EntryPointChanged, Criticality: schema.Breaking, Path: "mutation", Message: `Schema mutation root type "Mutation" was removed`},
+	}, changes)
+
+	// the reverse changes are all safe.
+	assert.Empty(t, schema.Diff(new, old).Breaking())
+}
@@ -0,0 +1,238 @@
+package schema
+
+import (
+	"github.com/chirino/graphql/internal/lexer"
+	"github.com/chirino/graphql/qerrors"
+)
+
+// typeExtension holds the definitions of an `extend` type definition until they get merged into
+// the extended type.
+//
+// http://facebook.github.io/graphql/draft/#sec-Type-Extensions
+type typeExtension struct {
+	typ NamedType
+	loc Location
+}
+
+// parseExtension parses the definition that follows the `extend` keyword.  Schema extensions are
+// applied right away, type extensions are returned since the extended type may be defined later on.
+func parseExtension(s *Schema, l *lexer.Lexer) *typeExtension {
+	switch x := l.ConsumeKeyword("schema", "type", "interface", "union", "enum", "input", "scalar"); x {
+	case "schema":
+		parseSchemaExtension(s, l)
+		return nil
+	case "scalar":
+		name, loc := l.ConsumeIdentInternWithLoc()
+		return &typeExtension{typ: &Scalar{Name: name, Directives: ParseDirectives(l)}, loc: loc}
+	case "type":
+		name, loc := l.ConsumeIdentInternWithLoc()
+		obj := &Object{Name: name}
+		obj.InterfaceNames = parseExtensionInterfaces(l)
+		obj.Directives = ParseDirectives(l)
+		if l.Peek() == '{' {
+			l.ConsumeToken('{')
+			obj.Fields = parseFieldsDef(l)
+			l.ConsumeToken('}')
+		}
+		return &typeExtension{typ: obj, loc: loc}
+	case "interface":
+		name, loc := l.ConsumeIdentInternWithLoc()
+		iface := &Interface{Name: name}
+		iface.InterfaceNames = parseExtensionInterfaces(l)
+		iface.Directives = ParseDirectives(l)
+		if l.Peek() == '{' {
+			l.ConsumeToken('{')
+			iface.Fields = parseFieldsDef(l)
+			l.ConsumeToken('}')
+		}
+		return &typeExtension{typ: iface, loc: loc}
+	case "union":
+		name, loc := l.ConsumeIdentInternWithLoc()
+		union := &Union{Name: name, Directives: ParseDirectives(l)}
+		if l.Peek() == '=' {
+			l.ConsumeToken('=')
+			if l.Peek() == '|' {
+				l.ConsumeToken('|')
+			}
+			union.TypeNames = []string{l.ConsumeIdentIntern()}
+			for l.Peek() == '|' {
+				l.ConsumeToken('|')
+				union.TypeNames = append(union.TypeNames, l.ConsumeIdentIntern())
+			}
+		}
+		return &typeExtension{typ: union, loc: loc}
+	case "enum":
+		name, loc := l.ConsumeIdentInternWithLoc()
+		enum := &Enum{Name: name, Directives: ParseDirectives(l)}
+		if l.Peek() == '{' {
+			l.ConsumeToken('{')
+			for l.Peek() != '}' {
+				v := &EnumValue{Desc: l.ConsumeDescription()}
+				v.Name, v.Loc = l.ConsumeIdentInternWithLoc()
+				v.Directives = ParseDirectives(l)
+				enum.Values = append(enum.Values, v)
+			}
+			l.ConsumeToken('}')
+		}
+		return &typeExtension{typ: enum, loc: loc}
+	default: // "input"
+		name, loc := l.ConsumeIdentInternWithLoc()
+		input := &InputObject{Name: name, Directives: ParseDirectives(l)}
+		if l.Peek() == '{' {
+			l.ConsumeToken('{')
+			for l.Peek() != '}' {
+				input.Fields = append(input.Fields, ParseInputValue(l))
+			}
+			l.ConsumeToken('}')
+		}
+		return &typeExtension{typ: input, loc: loc}
+	}
+}
+
+// parseExtensionInterfaces parses the optional `implements A & B` clause of an extension, which unlike
+// definitions can be the end of the extension so the interface names have to be separated with &.
+func parseExtensionInterfaces(l *lexer.Lexer) (names []string) {
+	if !l.PeekKeyword("implements") {
+		return nil
+	}
+	l.Consume()
+	if l.Peek() == '&' {
+		l.ConsumeToken('&')
+	}
+	names = append(names, l.ConsumeIdentIntern())
+	for l.Peek() == '&' {
+		l.ConsumeToken('&')
+		names = append(names, l.ConsumeIdentIntern())
+	}
+	return names
+}
+
+func parseSchemaExtension(s *Schema, l *lexer.Lexer) {
+	s.Directives = append(s.Directives, ParseDirectives(l)...)
+	if l.Peek() != '{' {
+		return
+	}
+	l.ConsumeToken('{')
+	for l.Peek() != '}' {
+		name := OperationType(l.ConsumeKeyword(string(Query), string(Mutation), string(Subscription)))
+		l.ConsumeToken(':')
+		typ := l.ConsumeIdentIntern()
+		if _, ok := s.EntryPointNames[name]; ok {
+			l.SyntaxError("the " + string(name) + " operation type is already defined")
+		}
+		s.EntryPointNames[name] = typ
+	}
+	l.ConsumeToken('}')
+}
+
+// applyExtensions merges the type extensions into the types they extend.
+func (s *Schema) applyExtensions(extensions []*typeExtension) error {
+	for _, ext := range extensions {
+		name := ext.typ.TypeName()
+		existing := s.Types[name]
+		if existing == nil {
+			return qerrors.Errorf("cannot extend type %q, it is not defined", name).WithLocations(ext.loc)
+		}
+		if existing.Kind() != ext.typ.Kind() {
+			return qerrors.Errorf("cannot extend type %q as %s, its kind is %s", name, ext.typ.Kind(), existing.Kind()).WithLocations(ext.loc)
+		}
+		var err *qerrors.Error
+		switch t := existing.(type) {
+		case *Scalar:
+			t.Directives = append(t.Directives, ext.typ.(*Scalar).Directives...)
+		case *Object:
+			err = t.extend(ext.typ.(*Object))
+		case *Interface:
+			err = t.extend(ext.typ.(*Interface))
+		case *Union:
+			err = t.extend(ext.typ.(*Union))
+		case *Enum:
+			err = t.extend(ext.typ.(*Enum))
+		case *InputObject:
+			err = t.extend(ext.typ.(*InputObject))
+		}
+		if err != nil {
+			return err.WithLocations(ext.loc)
+		}
+	}
+	return nil
+}
+
+func (t *Object) extend(ext *Object) *qerrors.Error {
+	if err := checkNewInterfaces(t.Name, t.InterfaceNames, ext.InterfaceNames); err != nil {
+		return err
+	}
+	if err := checkNewFields(t.Name, t.Fields, ext.Fields); err != nil {
+		return err
+	}
+	t.InterfaceNames = append(t.InterfaceNames, ext.InterfaceNames...)
+	t.Fields = append(t.Fields, ext.Fields...)
+	t.Directives = append(t.Directives, ext.Directives...)
+	return nil
+}
+
+func (t *Interface) extend(ext *Interface) *qerrors.Error {
+	if err := checkNewInterfaces(t.Name, t.InterfaceNames, ext.InterfaceNames); err != nil {
+		return err
+	}
+	if err := checkNewFields(t.Name, t.Fields, ext.Fields); err != nil {
+		return err
+	}
+	t.InterfaceNames = append(t.InterfaceNames, ext.InterfaceNames...)
+	t.Fields = append(t.Fields, ext.Fields...)
+	t.Directives = append(t.Directives, ext.Directives...)
+	return nil
+}
+
+func (t *Union) extend(ext *Union) *qerrors.Error {
+	for _, name := range ext.TypeNames {
+		if StringListGet(t.TypeNames, name) != nil {
+			return qerrors.Errorf("union %q already includes %q", t.Name, name)
+		}
+	}
+	t.TypeNames = append(t.TypeNames, ext.TypeNames...)
+	t.Directives = append(t.Directives, ext.Directives...)
+	return nil
+}
+
+func (t *Enum) extend(ext *Enum) *qerrors.Error {
+	for _, v := range ext.Values {
+		for _, existing := range t.Values {
+			if existing.Name == v.Name {
+				return qerrors.Errorf("enum %q already has the value %q", t.Name, v.Name)
+			}
+		}
+	}
+	t.Values = append(t.Values, ext.Values...)
+	t.Directives = append(t.Directives, ext.Directives...)
+	return nil
+}
+
+func (t *InputObject) extend(ext *InputObject) *qerrors.Error {
+	for _, f := range ext.Fields {
+		if t.Fields.Get(f.Name) != nil {
+			return qerrors.Errorf("input %q already has the field %q", t.Name, f.Name)
+		}
+	}
+	t.Fields = append(t.Fields, ext.Fields...)
+	t.Directives = append(t.Directives, ext.Directives...)
+	return nil
+}
+
+func checkNewFields(typeName string, fields FieldList, newFields FieldList) *qerrors.Error {
+	for _, f := range newFields {
+		if fields.Get(f.Name) != nil {
+			return qerrors.Errorf("type %q already has the field %q", typeName, f.Name)
+		}
+	}
+	return nil
+}
+
+func checkNewInterfaces(typeName string, names []string, newNames []string) *qerrors.Error {
+	for _, name := range newNames {
+		if StringListGet(names, name) != nil {
+			return qerrors.Errorf("type %q already implements %q", typeName, name)
+		}
+	}
+	return nil
+}
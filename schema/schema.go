@@ -47,9 +47,6 @@ type Schema struct {
 	// http://facebook.github.io/graphql/draft/#sec-Types
 	Types map[string]NamedType
 
-	// TODO: Type extensions?
-	// http://facebook.github.io/graphql/draft/#sec-Type-Extensions
-
 	// Directives are used to annotate various parts of a GraphQL document as an indicator that they
 	// should be evaluated differently by a validator, executor, or client tool such as a code
 	// generator.
@@ -122,6 +119,7 @@ type NamedType interface {
 // http://facebook.github.io/graphql/draft/#sec-Scalars
 type Scalar struct {
 	Name       string
+	Loc        qerrors.Location
 	Desc       Description
 	Directives DirectiveList
 	// TODO: Add a list of directives?
@@ -136,6 +134,7 @@ type Scalar struct {
 // http://facebook.github.io/graphql/draft/#sec-Objects
 type Object struct {
 	Name       string
+	Loc        qerrors.Location
 	Interfaces InterfaceList
 	Fields     FieldList `json:"fields"`
 	Desc       Description
@@ -152,10 +151,15 @@ type Object struct {
 // http://facebook.github.io/graphql/draft/#sec-Interfaces
 type Interface struct {
 	Name          string
+	Loc           qerrors.Location
 	PossibleTypes []*Object
 	Fields        FieldList // NOTE: the spec refers to this as `FieldsDefinition`.
 	Desc          Description
 	Directives    DirectiveList
+	// Interfaces are the interfaces implemented by this interface.
+	Interfaces InterfaceList
+
+	InterfaceNames []string
 }
 
 type InterfaceList []*Interface
@@ -207,6 +211,7 @@ func StringListSelect(l []string, keep func(d string) bool) []string {
 // http://facebook.github.io/graphql/draft/#sec-Unions
 type Union struct {
 	Name          string
+	Loc           qerrors.Location
 	PossibleTypes []*Object // NOTE: the spec refers to this as `UnionMemberTypes`.
 	Desc          Description
 	TypeNames     []string
@@ -220,6 +225,7 @@ type Union struct {
 // http://facebook.github.io/graphql/draft/#sec-Enums
 type Enum struct {
 	Name       string
+	Loc        qerrors.Location
 	Values     EnumValueList // NOTE: the spec refers to this as `EnumValuesDefinition`.
 	Desc       Description
 	Directives DirectiveList
@@ -227,12 +233,23 @@ type Enum struct {
 
 type EnumValueList []*EnumValue
 
+// Get returns the enum value with the given name, or nil when there is no such value.
+func (l EnumValueList) Get(name string) *EnumValue {
+	for _, v := range l {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
 // EnumValue types are unique values that may be serialized as a string: the name of the
 // represented value.
 //
 // http://facebook.github.io/graphql/draft/#EnumValueDefinition
 type EnumValue struct {
 	Name       string
+	Loc        qerrors.Location
 	Directives DirectiveList
 	Desc       Description
 	// TODO: Add a list of directives?
@@ -246,6 +263,7 @@ type EnumValue struct {
 // http://facebook.github.io/graphql/draft/#sec-Input-Objects
 type InputObject struct {
 	Name       string
+	Loc        qerrors.Location
 	Desc       Description
 	Fields     InputValueList
 	Directives DirectiveList
@@ -290,9 +308,12 @@ func (l FieldList) Select(keep func(d *Field) bool) FieldList {
 // http://facebook.github.io/graphql/draft/#sec-Type-System.Directives
 type DirectiveDecl struct {
 	Name string
+	Loc  qerrors.Location
 	Desc Description
 	Locs []string
 	Args InputValueList
+	// Repeatable directives can be used more than once at the same location.
+	Repeatable bool
 }
 
 func (*Schema) Kind() string { return "SCHEMA" }
@@ -364,11 +385,20 @@ func (t *Object) Sort() {
 func (t *Interface) Sort() {
 	t.Fields.Sort()
 	t.Directives.Sort()
+	sort.Slice(t.InterfaceNames, func(i, j int) bool {
+		return t.InterfaceNames[i] < t.InterfaceNames[j]
+	})
 }
 func (t *InputObject) Sort() {
 	t.Fields.Sort()
 	t.Directives.Sort()
 }
+
+// IsOneOf reports whether the input object is annotated with @oneOf, so exactly one of its
+// fields must be set to a non null value.
+func (t *InputObject) IsOneOf() bool {
+	return t.Directives.Get("oneOf") != nil
+}
 func (t *Union) Sort() {
 	t.Directives.Sort()
 	sort.Slice(t.TypeNames, func(i, j int) bool {
@@ -449,8 +479,9 @@ func (t *EnumValue) Sort() {
 // Field is a conceptual function which yields values.
 // http://facebook.github.io/graphql/draft/#FieldDefinition
 type Field struct {
-	Name       string         `json:"name"`
-	Args       InputValueList `json:"args"` // NOTE: the spec refers to this as `ArgumentsDefinition`.
+	Name       string           `json:"name"`
+	Loc        qerrors.Location `json:"-"`
+	Args       InputValueList   `json:"args"` // NOTE: the spec refers to this as `ArgumentsDefinition`.
 	Type       Type
 	Directives DirectiveList
 	Desc       Description `json:"desc"`
@@ -477,19 +508,92 @@ func New() *Schema {
 // Parse the schema string.
 func (s *Schema) Parse(schemaString string) error {
 	l := lexer.Get(schemaString)
-	err := l.CatchSyntaxError(func() { parseSchema(s, l) })
+	var extensions []*typeExtension
+	err := l.CatchSyntaxError(func() { extensions = parseSchema(s, l) })
 	lexer.Put(l)
 	if err != nil {
 		return err
 	}
+	if err := s.applyExtensions(extensions); err != nil {
+		return err
+	}
 	return s.ResolveTypes()
 }
 
+// resolveInterfaces finds the interfaces implemented by the named object or interface type.
+func (s *Schema) resolveInterfaces(typeName string, names []string) (InterfaceList, error) {
+	interfaces := make(InterfaceList, len(names))
+	for i, intfName := range names {
+		if intfName == typeName {
+			return nil, qerrors.Errorf("type %q cannot implement itself", typeName)
+		}
+		t, ok := s.Types[intfName]
+		if !ok {
+			return nil, qerrors.Errorf("interface %q not found", intfName)
+		}
+		intf, ok := t.(*Interface)
+		if !ok {
+			return nil, qerrors.Errorf("type %q is not an interface", intfName)
+		}
+		interfaces[i] = intf
+	}
+	return interfaces, nil
+}
+
+// checkImplementations verifies that a type defines the fields of the interfaces it implements and that it
+// also implements the interfaces that those interfaces implement.
+func checkImplementations(typeName string, fields FieldList, interfaces InterfaceList) error {
+	for _, intf := range interfaces {
+		for _, f := range intf.Fields {
+			if fields.Get(f.Name) == nil {
+				return qerrors.Errorf("type %q must define the field %q of interface %q", typeName, f.Name, intf.Name)
+			}
+		}
+		for _, name := range intf.InterfaceNames {
+			if name == typeName {
+				return qerrors.Errorf("type %q cannot implement %q, since %q implements %q", typeName, intf.Name, intf.Name, typeName)
+			}
+			if interfaces.Get(name) == nil {
+				return qerrors.Errorf("type %q must implement %q, since it is implemented by %q", typeName, name, intf.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// checkDeprecatedInputValues verifies that only optional arguments and input fields are deprecated.
+func checkDeprecatedInputValues(owner string, values InputValueList) error {
+	for _, v := range values {
+		if v.Directives.Get("deprecated") == nil {
+			continue
+		}
+		if _, ok := v.Type.(*NonNull); ok && v.Default == nil {
+			return qerrors.Errorf("required input value %q of %q cannot be deprecated", v.Name, owner)
+		}
+	}
+	return nil
+}
+
+// checkOneOf verifies that the fields of a @oneOf input object are nullable and have no default value.
+func checkOneOf(t *InputObject) error {
+	for _, f := range t.Fields {
+		if _, ok := f.Type.(*NonNull); ok {
+			return qerrors.Errorf("field %q of oneOf input %q must be nullable", f.Name, t.Name)
+		}
+		if f.Default != nil {
+			return qerrors.Errorf("field %q of oneOf input %q cannot have a default value", f.Name, t.Name)
+		}
+	}
+	return nil
+}
+
 func (s *Schema) ResolveTypes() error {
 
 	objects := []*Object{}
+	interfaces := []*Interface{}
 	unions := []*Union{}
 	enums := []*Enum{}
+	inputs := []*InputObject{}
 
 	for _, t := range s.Types {
 		if err := resolveNamedType(s, t); err != nil {
@@ -501,8 +605,10 @@ func (s *Schema) ResolveTypes() error {
 			objects = append(objects, t)
 		case *Interface:
 			t.Sort()
+			interfaces = append(interfaces, t)
 		case *InputObject:
 			t.Sort()
+			inputs = append(inputs, t)
 		case *Union:
 			t.Sort()
 			unions = append(unions, t)
@@ -536,20 +642,32 @@ func (s *Schema) ResolveTypes() error {
 		return objects[i].Name < objects[j].Name
 	})
 	for _, obj := range objects {
-		obj.Interfaces = make([]*Interface, len(obj.InterfaceNames))
-		for i, intfName := range obj.InterfaceNames {
-			t, ok := s.Types[intfName]
-			if !ok {
-				return qerrors.Errorf("interface %q not found", intfName)
-			}
-			intf, ok := t.(*Interface)
-			if !ok {
-				return qerrors.Errorf("type %q is not an interface", intfName)
-			}
-			obj.Interfaces[i] = intf
+		interfaces, err := s.resolveInterfaces(obj.Name, obj.InterfaceNames)
+		if err != nil {
+			return err
+		}
+		obj.Interfaces = interfaces
+		for _, intf := range interfaces {
 			intf.PossibleTypes = append(intf.PossibleTypes, obj)
 		}
 	}
+	for _, intf := range interfaces {
+		implemented, err := s.resolveInterfaces(intf.Name, intf.InterfaceNames)
+		if err != nil {
+			return err
+		}
+		intf.Interfaces = implemented
+	}
+	for _, obj := range objects {
+		if err := checkImplementations(obj.Name, obj.Fields, obj.Interfaces); err != nil {
+			return err
+		}
+	}
+	for _, intf := range interfaces {
+		if err := checkImplementations(intf.Name, intf.Fields, intf.Interfaces); err != nil {
+			return err
+		}
+	}
 	for _, union := range unions {
 		union.PossibleTypes = make([]*Object, len(union.TypeNames))
 		for i, name := range union.TypeNames {
@@ -576,6 +694,31 @@ func (s *Schema) ResolveTypes() error {
 		}
 	}
 
+	for _, obj := range objects {
+		for _, f := range obj.Fields {
+			if err := checkDeprecatedInputValues(obj.Name+"."+f.Name, f.Args); err != nil {
+				return err
+			}
+		}
+	}
+	for _, intf := range interfaces {
+		for _, f := range intf.Fields {
+			if err := checkDeprecatedInputValues(intf.Name+"."+f.Name, f.Args); err != nil {
+				return err
+			}
+		}
+	}
+	for _, input := range inputs {
+		if err := checkDeprecatedInputValues(input.Name, input.Fields); err != nil {
+			return err
+		}
+		if input.IsOneOf() {
+			if err := checkOneOf(input); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -787,9 +930,16 @@ func resolveNamedType(s *Schema, t NamedType) error {
 			}
 		}
 	case *InputObject:
+		if err := resolveDirectives(s, t.Directives); err != nil {
+			return err
+		}
 		if err := resolveInputObject(s, t.Fields); err != nil {
 			return err
 		}
+	case *Scalar:
+		if err := resolveDirectives(s, t.Directives); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -834,11 +984,14 @@ func resolveInputObject(s *Schema, values InputValueList) error {
 			return err
 		}
 		v.Type = t
+		if err := resolveDirectives(s, v.Directives); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func parseSchema(s *Schema, l *lexer.Lexer) {
+func parseSchema(s *Schema, l *lexer.Lexer) (extensions []*typeExtension) {
 	l.Consume()
 
 	for l.Peek() != scanner.EOF {
@@ -846,7 +999,7 @@ func parseSchema(s *Schema, l *lexer.Lexer) {
 		switch x := l.ConsumeIdentIntern(); x {
 
 		case "schema":
-			s.Directives = ParseDirectives(l)
+			s.Directives = append(s.Directives, ParseDirectives(l)...)
 			l.ConsumeToken('{')
 			for l.Peek() != '}' {
 				name := OperationType(l.ConsumeKeyword(string(Query), string(Mutation), string(Subscription)))
@@ -951,9 +1104,10 @@ func parseSchema(s *Schema, l *lexer.Lexer) {
 			s.Types[input.Name] = input
 
 		case "scalar":
-			name := l.ConsumeIdentIntern()
+			name, loc := l.ConsumeIdentInternWithLoc()
 			s.Types[name] = &Scalar{
 				Name:       name,
+				Loc:        loc,
 				Desc:       desc,
 				Directives: ParseDirectives(l),
 			}
@@ -963,31 +1117,22 @@ func parseSchema(s *Schema, l *lexer.Lexer) {
 			directive.Desc = desc
 			s.DeclaredDirectives[directive.Name] = directive
 
+		case "extend":
+			if ext := parseExtension(s, l); ext != nil {
+				extensions = append(extensions, ext)
+			}
+
 		default:
-			// TODO: Add support for type extensions.
-			l.SyntaxError(fmt.Sprintf(`unexpected %q, expecting "schema", "type", "enum", "interface", "union", "input", "scalar" or "directive"`, x))
+			l.SyntaxError(fmt.Sprintf(`unexpected %q, expecting "schema", "type", "enum", "interface", "union", "input", "scalar", "directive" or "extend"`, x))
 		}
 	}
+	return
 }
 
 func parseObjectDef(l *lexer.Lexer) *Object {
-	object := &Object{Name: l.ConsumeIdentIntern()}
-
-	if l.PeekKeyword("implements") {
-		l.Consume()
-		if l.Peek() == '&' {
-			l.ConsumeToken('&')
-		}
-		for {
-			object.InterfaceNames = append(object.InterfaceNames, l.ConsumeIdentIntern())
-			if l.Peek() == '&' {
-				l.ConsumeToken('&')
-			} else if l.Peek() == '@' || l.Peek() == '{' {
-				break
-			}
-		}
-	}
-
+	object := &Object{}
+	object.Name, object.Loc = l.ConsumeIdentInternWithLoc()
+	object.InterfaceNames = parseImplementsInterfaces(l)
 	object.Directives = ParseDirectives(l)
 
 	l.ConsumeToken('{')
@@ -1002,8 +1147,30 @@ func parseTypeName(l *lexer.Lexer) TypeName {
 	return TypeName{Name: name, NameLoc: loc}
 }
 
+// parseImplementsInterfaces parses the optional `implements A & B` clause of object and interface definitions.
+func parseImplementsInterfaces(l *lexer.Lexer) (names []string) {
+	if !l.PeekKeyword("implements") {
+		return nil
+	}
+	l.Consume()
+	if l.Peek() == '&' {
+		l.ConsumeToken('&')
+	}
+	for {
+		names = append(names, l.ConsumeIdentIntern())
+		if l.Peek() == '&' {
+			l.ConsumeToken('&')
+		} else if l.Peek() == '@' || l.Peek() == '{' {
+			break
+		}
+	}
+	return names
+}
+
 func parseInterfaceDef(l *lexer.Lexer) *Interface {
-	i := &Interface{Name: l.ConsumeIdentIntern()}
+	i := &Interface{}
+	i.Name, i.Loc = l.ConsumeIdentInternWithLoc()
+	i.InterfaceNames = parseImplementsInterfaces(l)
 	i.Directives = ParseDirectives(l)
 	l.ConsumeToken('{')
 	i.Fields = parseFieldsDef(l)
@@ -1013,7 +1180,8 @@ func parseInterfaceDef(l *lexer.Lexer) *Interface {
 }
 
 func parseUnionDef(l *lexer.Lexer) *Union {
-	union := &Union{Name: l.ConsumeIdentIntern()}
+	union := &Union{}
+	union.Name, union.Loc = l.ConsumeIdentInternWithLoc()
 	union.Directives = ParseDirectives(l)
 	l.ConsumeToken('=')
 	union.TypeNames = []string{l.ConsumeIdentIntern()}
@@ -1027,7 +1195,7 @@ func parseUnionDef(l *lexer.Lexer) *Union {
 
 func parseInputDef(l *lexer.Lexer) *InputObject {
 	i := &InputObject{}
-	i.Name = l.ConsumeIdentIntern()
+	i.Name, i.Loc = l.ConsumeIdentInternWithLoc()
 	i.Directives = ParseDirectives(l)
 	l.ConsumeToken('{')
 	for l.Peek() != '}' {
@@ -1038,15 +1206,14 @@ func parseInputDef(l *lexer.Lexer) *InputObject {
 }
 
 func parseEnumDef(l *lexer.Lexer) *Enum {
-	enum := &Enum{Name: l.ConsumeIdentIntern()}
+	enum := &Enum{}
+	enum.Name, enum.Loc = l.ConsumeIdentInternWithLoc()
 	enum.Directives = ParseDirectives(l)
 	l.ConsumeToken('{')
 	for l.Peek() != '}' {
-		v := &EnumValue{
-			Desc:       l.ConsumeDescription(),
-			Name:       l.ConsumeIdentIntern(),
-			Directives: ParseDirectives(l),
-		}
+		v := &EnumValue{Desc: l.ConsumeDescription()}
+		v.Name, v.Loc = l.ConsumeIdentInternWithLoc()
+		v.Directives = ParseDirectives(l)
 
 		enum.Values = append(enum.Values, v)
 	}
@@ -1056,7 +1223,8 @@ func parseEnumDef(l *lexer.Lexer) *Enum {
 
 func parseDirectiveDef(l *lexer.Lexer) *DirectiveDecl {
 	l.ConsumeToken('@')
-	d := &DirectiveDecl{Name: l.ConsumeIdentIntern()}
+	d := &DirectiveDecl{}
+	d.Name, d.Loc = l.ConsumeIdentInternWithLoc()
 
 	if l.Peek() == '(' {
 		l.ConsumeToken('(')
@@ -1067,6 +1235,10 @@ func parseDirectiveDef(l *lexer.Lexer) *DirectiveDecl {
 		l.ConsumeToken(')')
 	}
 
+	if l.PeekKeyword("repeatable") {
+		l.Consume()
+		d.Repeatable = true
+	}
 	l.ConsumeKeyword("on")
 
 	for {
@@ -1085,7 +1257,7 @@ func parseFieldsDef(l *lexer.Lexer) FieldList {
 	for l.Peek() != '}' {
 		f := &Field{}
 		f.Desc = l.ConsumeDescription()
-		f.Name = l.ConsumeIdentIntern()
+		f.Name, f.Loc = l.ConsumeIdentInternWithLoc()
 		if l.Peek() == '(' {
 			l.ConsumeToken('(')
 			for l.Peek() != ')' {
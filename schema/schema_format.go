@@ -43,7 +43,9 @@ func (s *Schema) WriteTo(out io.StringWriter) {
 		value.WriteTo(out)
 	}
 
-	out.WriteString("schema {\n")
+	out.WriteString("schema")
+	writeDirectives(out, s.Directives)
+	out.WriteString(" {\n")
 	for _, entry := range mapToSortedArray(s.EntryPoints) {
 		key := entry.Key.(OperationType)
 		value := entry.Value.(NamedType)
@@ -56,6 +58,7 @@ func (t *Scalar) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString("scalar ")
 	out.WriteString(t.Name)
+	writeDirectives(out, t.Directives)
 	out.WriteString("\n")
 }
 
@@ -64,6 +67,9 @@ func (t *DirectiveDecl) WriteTo(out io.StringWriter) {
 	out.WriteString("directive @")
 	out.WriteString(t.Name)
 	t.Args.WriteTo(out)
+	if t.Repeatable {
+		out.WriteString(" repeatable")
+	}
 	out.WriteString(" on ")
 	for i, loc := range t.Locs {
 		if i != 0 {
@@ -88,7 +94,6 @@ func (t *Object) WriteTo(out io.StringWriter) {
 			out.WriteString(intf.Name)
 		}
 		out.WriteString(" ")
-		t.Directives.WriteTo(out)
 	}
 	if len(t.Directives) > 0 {
 		out.WriteString(" ")
@@ -116,6 +121,17 @@ func (t *Interface) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString("interface ")
 	out.WriteString(t.Name)
+	if len(t.Interfaces) > 0 {
+		out.WriteString(" implements")
+		for i, intf := range t.Interfaces {
+			if i != 0 {
+				out.WriteString(" &")
+			}
+			out.WriteString(" ")
+			out.WriteString(intf.Name)
+		}
+	}
+	writeDirectives(out, t.Directives)
 	out.WriteString(" {\n")
 	for _, f := range t.Fields {
 		i := &indent{}
@@ -129,6 +145,7 @@ func (t *Union) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString("union ")
 	out.WriteString(t.Name)
+	writeDirectives(out, t.Directives)
 	out.WriteString(" = ")
 	for i, f := range t.PossibleTypes {
 		if i != 0 {
@@ -143,6 +160,7 @@ func (t *Enum) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString("enum ")
 	out.WriteString(t.Name)
+	writeDirectives(out, t.Directives)
 	out.WriteString(" {\n")
 	for _, f := range t.Values {
 		i := &indent{}
@@ -157,6 +175,7 @@ func (t *InputObject) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString("input ")
 	out.WriteString(t.Name)
+	writeDirectives(out, t.Directives)
 	out.WriteString(" {\n")
 	for _, f := range t.Fields {
 		i := &indent{}
@@ -170,7 +189,7 @@ func (t *InputObject) WriteTo(out io.StringWriter) {
 func (t *EnumValue) WriteTo(out io.StringWriter) {
 	writeDescription(out, t.Desc)
 	out.WriteString(t.Name)
-	t.Directives.WriteTo(out)
+	writeDirectives(out, t.Directives)
 }
 
 func (t *Field) WriteTo(out io.StringWriter) {
@@ -193,6 +212,14 @@ func (t DirectiveList) WriteTo(out io.StringWriter) {
 	}
 }
 
+// writeDirectives writes the directives separated from the preceding definition by a space.
+func writeDirectives(out io.StringWriter, directives DirectiveList) {
+	if len(directives) > 0 {
+		out.WriteString(" ")
+		directives.WriteTo(out)
+	}
+}
+
 func (t *Directive) WriteTo(out io.StringWriter) {
 	out.WriteString("@")
 	out.WriteString(t.Name)
@@ -272,6 +299,7 @@ func (t *InputValue) WriteTo(out io.StringWriter) {
 		out.WriteString("=")
 		t.Default.WriteTo(out)
 	}
+	writeDirectives(out, t.Directives)
 }
 
 func (lit *BasicLit) WriteTo(out io.StringWriter) {
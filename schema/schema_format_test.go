@@ -203,3 +203,171 @@ schema {
 }
 `, buf.String())
 }
+
+func TestWriteSchemaFormatWithExtensions(t *testing.T) {
+	s := schema.New()
+	err := s.Parse(`
+extend type Query implements Node {
+  id: ID!
+}
+type Query {
+  hi: String
+  name: String
+}
+interface Node {
+  name: String
+}
+extend interface Node {
+  id: ID!
+}
+type Mutation {
+  hi: String
+}
+extend schema @tag(name: "schema") {
+  mutation: Mutation
+}
+union Result = Query
+extend union Result @tag(name: "union") = Mutation
+enum Color { RED }
+extend enum Color @tag(name: "enum") { BLUE @deprecated }
+input Filter { name: String }
+extend input Filter @tag(name: "input") { limit: Int }
+scalar Time
+extend scalar Time @deprecated
+extend type Mutation @tag(name: "object")
+extend interface Node @tag(name: "interface")
+directive @tag(name: String!) on SCHEMA | OBJECT | INTERFACE | UNION | ENUM | INPUT_OBJECT
+schema {
+  query: Query
+}
+`)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	s.WriteTo(buf)
+	written := buf.String()
+	assert.Equal(t, `directive @tag(name:String!) on SCHEMA | OBJECT | INTERFACE | UNION | ENUM | INPUT_OBJECT
+enum Color @tag(name:"enum") {
+  BLUE @deprecated(reason:"No longer supported")
+  RED
+}
+input Filter @tag(name:"input") {
+  limit:Int
+  name:String
+}
+type Mutation @tag(name:"object") {
+  hi:String
+}
+interface Node @tag(name:"interface") {
+  id:ID!
+  name:String
+}
+type Query implements Node  {
+  hi:String
+  id:ID!
+  name:String
+}
+union Result @tag(name:"union") = Mutation | Query
+scalar Time @deprecated(reason:"No longer supported")
+schema @tag(name:"schema") {
+  mutation: Mutation
+  query: Query
+}
+`, written)
+
+	// the merged schema parses back to the same schema.
+	s = schema.New()
+	assert.NoError(t, s.Parse(written))
+	buf = &bytes.Buffer{}
+	s.WriteTo(buf)
+	assert.Equal(t, written, buf.String())
+
+	s = schema.New()
+	err = s.Parse(`
+type Query { hi: String }
+extend type Missing { hi: String }
+`)
+	assert.EqualError(t, err, `graphql: cannot extend type "Missing", it is not defined (line 3, column 13)`)
+
+	s = schema.New()
+	err = s.Parse(`
+type Query { hi: String }
+extend type Query { hi: String }
+`)
+	assert.EqualError(t, err, `graphql: type "Query" already has the field "hi" (line 3, column 13)`)
+
+	s = schema.New()
+	err = s.Parse(`
+scalar Query
+extend type Query { hi: String }
+`)
+	assert.EqualError(t, err, `graphql: cannot extend type "Query" as OBJECT, its kind is SCALAR (line 3, column 13)`)
+}
+
+func TestWriteSchemaFormatWithInterfaceImplementations(t *testing.T) {
+	s := schema.New()
+	err := s.Parse(`
+directive @tag(name: String) repeatable on OBJECT | FIELD
+interface Node {
+  id: ID!
+}
+interface Resource implements Node {
+  id: ID!
+  url: String
+}
+type Image implements Resource & Node @tag(name: "a") @tag(name: "b") {
+  id: ID!
+  url: String
+}
+schema {
+  query: Image
+}
+`)
+	assert.NoError(t, err)
+	buf := &bytes.Buffer{}
+	s.WriteTo(buf)
+	written := buf.String()
+	assert.Equal(t, `directive @tag(name:String) repeatable on OBJECT | FIELD
+type Image implements Node & Resource  @tag(name:"a"), @tag(name:"b") {
+  id:ID!
+  url:String
+}
+interface Node {
+  id:ID!
+}
+interface Resource implements Node {
+  id:ID!
+  url:String
+}
+schema {
+  query: Image
+}
+`, written)
+
+	// the written schema parses back to the same schema.
+	s = schema.New()
+	assert.NoError(t, s.Parse(written))
+	buf = &bytes.Buffer{}
+	s.WriteTo(buf)
+	assert.Equal(t, written, buf.String())
+
+	s = schema.New()
+	err = s.Parse(`
+interface Node { id: ID! }
+interface Resource implements Node { url: String }
+`)
+	assert.EqualError(t, err, `graphql: type "Resource" must define the field "id" of interface "Node"`)
+
+	s = schema.New()
+	err = s.Parse(`
+interface Node { id: ID! }
+interface Resource implements Node { id: ID! }
+type Image implements Resource { id: ID! }
+`)
+	assert.EqualError(t, err, `graphql: type "Image" must implement "Node", since it is implemented by "Resource"`)
+
+	s = schema.New()
+	err = s.Parse(`
+interface Node implements Node { id: ID! }
+`)
+	assert.EqualError(t, err, `graphql: type "Node" cannot implement itself`)
+}